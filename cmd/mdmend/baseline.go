@@ -0,0 +1,73 @@
+package main
+
+import (
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/mohitmishra786/mdmend/internal/baseline"
+	"github.com/mohitmishra786/mdmend/internal/rules"
+)
+
+// loadBaseline prepares --baseline and --write-baseline. Both disable the
+// lint cache, whose stored counts do not account for the baseline.
+func loadBaseline(opts *lintOptions) error {
+	if opts.baselinePath != "" && opts.writeBaseline != "" {
+		return fmt.Errorf("--baseline and --write-baseline cannot be combined")
+	}
+	switch {
+	case opts.writeBaseline != "":
+		opts.baseline = baseline.New()
+	case opts.baselinePath != "":
+		b, err := baseline.Load(opts.baselinePath)
+		if err != nil {
+			return fmt.Errorf("loading baseline: %w", err)
+		}
+		opts.baseline = b
+	default:
+		return nil
+	}
+	opts.noCache = true
+	return nil
+}
+
+// applyBaseline records violations when writing a baseline, or drops the
+// ones already in the baseline.
+func applyBaseline(violations []rules.Violation, path string, content []byte, opts *lintOptions) []rules.Violation {
+	if opts.baseline == nil {
+		return violations
+	}
+	if opts.writeBaseline != "" {
+		opts.baseline.Add(path, string(content), violations)
+		return violations
+	}
+	return opts.baseline.Filter(path, string(content), violations)
+}
+
+// finishLint writes the baseline or reports baseline entries that are now
+// fixed, then exits according to the remaining violations.
+func finishLint(totalViolations int, opts *lintOptions) error {
+	var out io.Writer = os.Stdout
+	if opts.output == "json" || opts.output == "sarif" {
+		out = os.Stderr
+	}
+
+	if opts.writeBaseline != "" {
+		if err := opts.baseline.Save(opts.writeBaseline); err != nil {
+			return fmt.Errorf("writing baseline: %w", err)
+		}
+		_, _ = fmt.Fprintf(os.Stderr, "Wrote %d violation(s) to %s\n", opts.baseline.Len(), opts.writeBaseline)
+		return nil
+	}
+
+	if opts.baseline != nil && !opts.quiet {
+		if fixed := opts.baseline.Fixed(); len(fixed) > 0 {
+			_, _ = fmt.Fprintf(out, "\n  %d baseline entr(ies) no longer occur; run with --write-baseline %s to tighten it:\n", len(fixed), opts.baselinePath)
+			for _, e := range fixed {
+				_, _ = fmt.Fprintf(out, "    %s %s (x%d)\n", e.File, e.Rule, e.Count)
+			}
+		}
+	}
+
+	return exitForViolations(totalViolations, opts)
+}
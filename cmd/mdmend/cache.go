@@ -4,6 +4,7 @@ import (
 	"fmt"
 
 	"github.com/mohitmishra786/mdmend/internal/cache"
+	"github.com/mohitmishra786/mdmend/internal/linkcheck"
 	"github.com/spf13/cobra"
 )
 
@@ -20,7 +21,7 @@ func newCacheCmd() *cobra.Command {
 func newCacheClearCmd() *cobra.Command {
 	return &cobra.Command{
 		Use:   "clear",
-		Short: "Clear the lint result cache",
+		Short: "Clear the lint result and link check caches",
 		RunE: func(cmd *cobra.Command, args []string) error {
 			c, err := cache.Load("")
 			if err != nil {
@@ -30,6 +31,15 @@ func newCacheClearCmd() *cobra.Command {
 				return err
 			}
 			fmt.Printf("Cleared cache at %s\n", c.Path())
+
+			links, err := linkcheck.LoadCache("", 0)
+			if err != nil {
+				return err
+			}
+			if err := links.Clear(); err != nil {
+				return err
+			}
+			fmt.Printf("Cleared link cache at %s\n", links.Path())
 			return nil
 		},
 	}
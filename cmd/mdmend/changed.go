@@ -0,0 +1,93 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/mohitmishra786/mdmend/internal/config"
+	"github.com/mohitmishra786/mdmend/internal/rules"
+	"github.com/mohitmishra786/mdmend/internal/vcs"
+	"github.com/mohitmishra786/mdmend/internal/walker"
+)
+
+// gitChanges collects the files selected by --changed, --since and --staged.
+// It returns nil when none of them is set.
+func gitChanges(opts globalOptions) (*vcs.Changes, error) {
+	if opts.changed && opts.staged {
+		return nil, fmt.Errorf("--changed and --staged cannot be combined")
+	}
+	switch {
+	case opts.staged:
+		return vcs.Staged(".")
+	case opts.changed || opts.since != "" || opts.changedLines:
+		return vcs.Changed(".", opts.since)
+	}
+	return nil, nil
+}
+
+type walkResult struct {
+	files   []string
+	skipped []walker.Skipped
+	changes *vcs.Changes
+}
+
+// walkFiles expands args into Markdown files, keeping only files changed in
+// git when --changed or --staged is set.
+func walkFiles(cfg *config.Config, opts globalOptions, args []string, extra ...walker.Option) (walkResult, error) {
+	changes, err := gitChanges(opts)
+	if err != nil {
+		return walkResult{}, err
+	}
+
+	if changes != nil {
+		extra = append(extra, walker.WithFilter(changes.Contains))
+	}
+
+	w := newWalker(cfg, opts, extra...)
+	files, err := w.Walk(args)
+	if err != nil {
+		return walkResult{}, err
+	}
+	return walkResult{files: files, skipped: w.Skipped(), changes: changes}, nil
+}
+
+// reportDiscovery warns about files skipped for their size and, with
+// --verbose, lists every discovered and skipped path.
+func reportDiscovery(walked walkResult, elapsed time.Duration, opts globalOptions) {
+	for _, s := range walked.skipped {
+		if s.Reason == walker.SkipTooLarge && !opts.verbose {
+			fmt.Fprintf(os.Stderr, "warning: skipping %s: %s\n", s.Path, s.Reason)
+		}
+	}
+
+	if !opts.verbose || opts.quiet {
+		return
+	}
+	fmt.Printf("\n  Discovered %d file(s) in %s\n", len(walked.files), elapsed.Round(time.Millisecond))
+	for _, f := range walked.files {
+		fmt.Printf("    %s\n", f)
+	}
+	if len(walked.skipped) > 0 {
+		fmt.Printf("  Skipped %d path(s)\n", len(walked.skipped))
+		for _, s := range walked.skipped {
+			fmt.Printf("    %s (%s)\n", s.Path, s.Reason)
+		}
+	}
+	fmt.Println()
+}
+
+// applyChangedLinesFilter drops violations outside the lines changed in git
+// when --changed-lines is set.
+func applyChangedLinesFilter(violations []rules.Violation, path string, opts *lintOptions) []rules.Violation {
+	if !opts.changedLines || opts.changes == nil {
+		return violations
+	}
+	var filtered []rules.Violation
+	for _, v := range violations {
+		if opts.changes.LineChanged(path, v.Line) {
+			filtered = append(filtered, v)
+		}
+	}
+	return filtered
+}
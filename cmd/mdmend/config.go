@@ -0,0 +1,139 @@
+package main
+
+import (
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/fatih/color"
+	"github.com/mohitmishra786/mdmend/internal/config"
+	"github.com/mohitmishra786/mdmend/internal/rules"
+	"github.com/spf13/cobra"
+)
+
+type configExportOptions struct {
+	format string
+	output string
+	report bool
+}
+
+func newConfigCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "config",
+		Short: "Inspect and convert mdmend configuration",
+	}
+
+	cmd.AddCommand(newConfigExportCmd())
+	return cmd
+}
+
+func newConfigExportCmd() *cobra.Command {
+	opts := &configExportOptions{}
+
+	cmd := &cobra.Command{
+		Use:   "export",
+		Short: "Export the active configuration for another tool",
+		Long: `Export the active mdmend configuration as an equivalent markdownlint config.
+
+Rules that mdmend does not implement are disabled in the exported config so
+both tools check the same rules. Use --report to print a migration report of
+rules that behave differently between mdmend and markdownlint.
+
+Examples:
+  mdmend config export --format markdownlint-json
+  mdmend config export --format markdownlint-yaml --output .markdownlint.yaml
+  mdmend config export --format markdownlint-json --report`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runConfigExport(opts, globalOpts)
+		},
+	}
+
+	cmd.Flags().StringVar(&opts.format, "format", "markdownlint-json", "Export format: markdownlint-json|markdownlint-yaml")
+	cmd.Flags().StringVar(&opts.output, "output", "", "Write to file instead of stdout")
+	cmd.Flags().BoolVar(&opts.report, "report", false, "Print a migration report to stderr")
+
+	return cmd
+}
+
+func runConfigExport(opts *configExportOptions, gopts globalOptions) error {
+	if gopts.noColor {
+		color.NoColor = true
+	}
+
+	cfg, err := loadConfig(gopts)
+	if err != nil {
+		return err
+	}
+
+	var data []byte
+	switch opts.format {
+	case "markdownlint-json", "markdownlint":
+		data, err = config.ToMarkdownlintJSON(cfg, rules.IDs())
+	case "markdownlint-yaml":
+		data, err = config.ToMarkdownlintYAML(cfg, rules.IDs())
+	default:
+		return fmt.Errorf("invalid format %q: use markdownlint-json or markdownlint-yaml", opts.format)
+	}
+	if err != nil {
+		return err
+	}
+
+	if opts.output == "" {
+		if _, err := os.Stdout.Write(data); err != nil {
+			return err
+		}
+	} else {
+		if err := os.WriteFile(opts.output, data, 0o644); err != nil {
+			return err
+		}
+		fmt.Fprintf(os.Stderr, "Wrote %s\n", opts.output)
+	}
+
+	if opts.report {
+		printMigrationReport(os.Stderr, cfg)
+	}
+	return nil
+}
+
+func printMigrationReport(w io.Writer, cfg *config.Config) {
+	cyan := color.New(color.FgCyan).SprintFunc()
+	yellow := color.New(color.FgYellow).SprintFunc()
+	bold := color.New(color.Bold).SprintFunc()
+
+	_, _ = fmt.Fprintf(w, "\n  %s\n\n", bold("Migration Report"))
+
+	sections := []struct {
+		compat rules.Compatibility
+		title  string
+	}{
+		{rules.CompatDifferent, "Behaves differently in mdmend"},
+		{rules.CompatMdmendOnly, "Only checked by mdmend"},
+		{rules.CompatMarkdownlintOnly, "Only checked by markdownlint (disabled in export)"},
+	}
+
+	notes := rules.MigrationReport(cfg)
+	for _, section := range sections {
+		_, _ = fmt.Fprintf(w, "  %s\n", bold(section.title))
+		for _, n := range notes {
+			if n.Compat != section.compat {
+				continue
+			}
+			state := ""
+			if section.compat != rules.CompatMarkdownlintOnly && !n.Enabled {
+				state = yellow(" (disabled)")
+			}
+			_, _ = fmt.Fprintf(w, "    %-8s  %-34s  %s%s\n", cyan(n.Rule), n.Name, n.Note, state)
+		}
+		_, _ = fmt.Fprintln(w)
+	}
+
+	warnings := config.MarkdownlintExportWarnings(cfg)
+	if len(warnings) > 0 {
+		_, _ = fmt.Fprintf(w, "  %s\n", bold("Settings not exported"))
+		for _, warning := range warnings {
+			_, _ = fmt.Fprintf(w, "    - %s\n", warning)
+		}
+		_, _ = fmt.Fprintln(w)
+	}
+}
@@ -0,0 +1,27 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestFixAllowInvalidUTF8(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "latin1.md")
+	if err := os.WriteFile(path, []byte("# Caf\xe9\n\nCaf\xe9 au lait. \n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	rootCmd.SetArgs([]string{"fix", "--no-cache", "--quiet", "--allow-invalid-utf8", path})
+	if err := rootCmd.Execute(); err != nil {
+		t.Fatalf("fix error = %v", err)
+	}
+
+	got, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := "# Caf\xe9\n\nCaf\xe9 au lait.\n"; string(got) != want {
+		t.Errorf("fixed file = %q, want %q", got, want)
+	}
+}
@@ -0,0 +1,91 @@
+package main
+
+import (
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/mohitmishra786/mdmend/internal/config"
+	"github.com/mohitmishra786/mdmend/internal/fixer"
+	"github.com/mohitmishra786/mdmend/internal/reporter"
+)
+
+// runFixCheck runs the fixer without writing and reports the files it would
+// change. Like a formatter's check mode, it exits 1 when any file would
+// change or could not be read, regardless of violations fix cannot repair.
+func runFixCheck(files []string, cfg *config.Config, opts *fixOptions) error {
+	fixers := make(map[string]*fixer.Fixer)
+	dr := reporter.NewDiffReporter()
+	var results []reporter.JSONFileResult
+	changed := 0
+	unreadable := 0
+
+	for _, path := range files {
+		content, err := os.ReadFile(path)
+		if err != nil {
+			unreadable++
+			if opts.output == "json" {
+				results = append(results, reporter.JSONFileResult{Path: path, Error: err.Error()})
+			} else {
+				fmt.Fprintf(os.Stderr, "Error reading %s: %v\n", path, err)
+			}
+			continue
+		}
+
+		f := fixerFor(fixers, cfg, path)
+		result := f.Fix(string(content), path)
+		if !result.Changed {
+			continue
+		}
+		changed++
+
+		if opts.output == "json" {
+			results = append(results, reporter.JSONFileResult{
+				Path:        path,
+				Violations:  []reporter.JSONViolation{},
+				Fixed:       result.Fixes,
+				WouldChange: true,
+			})
+			continue
+		}
+		if !opts.quiet {
+			fmt.Printf("would fix %s (%d fix(es))\n", path, result.Fixes)
+		}
+		if opts.diff {
+			if err := dr.Diff(path, string(content), result.Content); err != nil {
+				fmt.Fprintf(os.Stderr, "Error generating diff for %s: %v\n", path, err)
+			}
+		}
+	}
+
+	if opts.output == "json" {
+		if results == nil {
+			results = []reporter.JSONFileResult{}
+		}
+		if err := reporter.NewJSONReporter().OutputResults(results, reporter.JSONSummary{
+			TotalFiles:    len(files),
+			FilesToChange: changed,
+			Errors:        unreadable,
+		}); err != nil {
+			return err
+		}
+	} else {
+		printCheckSummary(os.Stdout, len(files), changed, unreadable)
+	}
+
+	if (changed > 0 || unreadable > 0) && !opts.exitZero {
+		os.Exit(1)
+	}
+	return nil
+}
+
+func printCheckSummary(w io.Writer, total, changed, unreadable int) {
+	switch {
+	case unreadable > 0:
+		_, _ = fmt.Fprintf(w, "%d files scanned — %d file(s) would be changed, %d could not be read\n", total, changed, unreadable)
+	case changed == 0:
+		_, _ = fmt.Fprintf(w, "%d files scanned — all already fixed\n", total)
+	default:
+		_, _ = fmt.Fprintf(w, "%d files scanned — %d file(s) would be changed\n", total, changed)
+	}
+}
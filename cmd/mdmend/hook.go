@@ -0,0 +1,195 @@
+package main
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/fatih/color"
+	"github.com/mohitmishra786/mdmend/internal/fixer"
+	"github.com/mohitmishra786/mdmend/internal/reporter"
+	"github.com/mohitmishra786/mdmend/internal/vcs"
+	"github.com/spf13/cobra"
+)
+
+const hookMarker = "# Installed by mdmend hook install"
+
+const hookScript = `#!/bin/sh
+` + hookMarker + `
+exec mdmend hook run
+`
+
+func newHookCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "hook",
+		Short: "Install or run the git pre-commit hook",
+	}
+
+	cmd.AddCommand(newHookInstallCmd())
+	cmd.AddCommand(newHookRunCmd())
+	return cmd
+}
+
+func newHookInstallCmd() *cobra.Command {
+	var force bool
+
+	cmd := &cobra.Command{
+		Use:   "install",
+		Short: "Write a pre-commit hook that runs 'mdmend hook run'",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runHookInstall(force)
+		},
+	}
+
+	cmd.Flags().BoolVar(&force, "force", false, "Overwrite an existing pre-commit hook")
+	return cmd
+}
+
+func newHookRunCmd() *cobra.Command {
+	var noFix bool
+
+	cmd := &cobra.Command{
+		Use:   "run",
+		Short: "Lint and fix staged Markdown, re-staging fixed files",
+		Long: `Lint and fix the staged version of every staged Markdown file.
+
+The staged content is checked rather than the working tree, so partially
+staged files are handled correctly. Fixes are written to the index and
+applied to the working tree copy as well. Exits 1 when violations that
+cannot be fixed automatically remain.`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runHook(globalOpts, noFix)
+		},
+	}
+
+	cmd.Flags().BoolVar(&noFix, "no-fix", false, "Only lint staged files; do not fix or re-stage them")
+	return cmd
+}
+
+func runHookInstall(force bool) error {
+	dir, err := vcs.HooksDir(".")
+	if err != nil {
+		return err
+	}
+	path := filepath.Join(dir, "pre-commit")
+
+	if existing, err := os.ReadFile(path); err == nil {
+		if !bytes.Contains(existing, []byte(hookMarker)) && !force {
+			return fmt.Errorf("%s already exists; rerun with --force to replace it", path)
+		}
+	}
+
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return err
+	}
+	if err := os.WriteFile(path, []byte(hookScript), 0o755); err != nil {
+		return err
+	}
+	fmt.Printf("Installed pre-commit hook at %s\n", path)
+	return nil
+}
+
+func runHook(opts globalOptions, noFix bool) error {
+	if opts.noColor {
+		color.NoColor = true
+	}
+
+	cfg, err := loadConfig(opts)
+	if err != nil {
+		return err
+	}
+
+	root, err := vcs.TopLevel(".")
+	if err != nil {
+		return err
+	}
+	changes, err := vcs.Staged(root)
+	if err != nil {
+		return err
+	}
+
+	w := newWalker(cfg, opts)
+	cr := reporter.NewConsoleReporter(opts.noColor)
+	fixers := make(map[string]*fixer.Fixer)
+
+	checked := 0
+	fixed := 0
+	remaining := 0
+	for _, abs := range changes.Files() {
+		path := displayPath(abs)
+		if !w.Includes(path) {
+			continue
+		}
+		checked++
+
+		staged, err := vcs.StagedContent(root, abs)
+		if err != nil {
+			return err
+		}
+
+		f := fixerFor(fixers, cfg, path)
+		content := string(staged)
+		if !noFix {
+			result := f.Fix(content, path)
+			if result.Changed {
+				if err := vcs.Stage(root, abs, []byte(result.Content)); err != nil {
+					return err
+				}
+				if err := fixWorkingTree(f, abs, path); err != nil {
+					return err
+				}
+				content = result.Content
+				fixed++
+				if !opts.quiet {
+					fmt.Printf("  fixed and re-staged %s\n", path)
+				}
+			}
+		}
+
+		violations := applyOnlyFilter(f.Lint(content, path), opts.only)
+		if len(violations) > 0 {
+			remaining += len(violations)
+			if err := cr.Report(path, violations); err != nil {
+				fmt.Fprintf(os.Stderr, "Error reporting %s: %v\n", path, err)
+			}
+		}
+	}
+
+	if !opts.quiet && checked > 0 {
+		fmt.Printf("\n  %d staged file(s) checked · %d fixed · %d violation(s) remaining\n", checked, fixed, remaining)
+	}
+	if remaining > 0 && !opts.exitZero {
+		os.Exit(1)
+	}
+	return nil
+}
+
+// fixWorkingTree applies the same fixes to the working tree copy so the
+// re-staged fixes do not show up as unstaged reversions.
+func fixWorkingTree(f *fixer.Fixer, abs, path string) error {
+	content, err := os.ReadFile(abs)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil
+		}
+		return err
+	}
+	result := f.Fix(string(content), path)
+	if !result.Changed {
+		return nil
+	}
+	return fixer.NewPatcher(abs).Expect(content).Apply(result.Content)
+}
+
+// displayPath returns path relative to the working directory when possible.
+func displayPath(path string) string {
+	cwd, err := os.Getwd()
+	if err != nil {
+		return path
+	}
+	if rel, err := filepath.Rel(cwd, path); err == nil {
+		return rel
+	}
+	return path
+}
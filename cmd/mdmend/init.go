@@ -4,6 +4,7 @@ import (
 	"fmt"
 	"os"
 	"path/filepath"
+	"strings"
 
 	"github.com/mohitmishra786/mdmend/internal/config"
 	"github.com/spf13/cobra"
@@ -23,7 +24,8 @@ func newInitCmd() *cobra.Command {
 		Short: "Create a new mdmend configuration file",
 		Long: `Create a new .mdmend.yml configuration file.
 
-Use --from-markdownlint to convert an existing .markdownlint.json file.
+Use --from-markdownlint to convert an existing .markdownlint.json or
+.markdownlint.yaml file.
 
 Examples:
   mdmend init
@@ -35,7 +37,7 @@ Examples:
 		},
 	}
 
-	cmd.Flags().StringVar(&opts.fromMarkdownlint, "from-markdownlint", "", "Import settings from a markdownlint JSON config")
+	cmd.Flags().StringVar(&opts.fromMarkdownlint, "from-markdownlint", "", "Import settings from a markdownlint JSON or YAML config")
 	cmd.Flags().StringVar(&opts.output, "output", ".mdmend.yml", "Output config file path")
 	cmd.Flags().BoolVar(&opts.force, "force", false, "Overwrite existing config file")
 
@@ -91,5 +93,10 @@ func loadMarkdownlintConfig(path string) (*config.Config, error) {
 	if err != nil {
 		return nil, err
 	}
-	return config.ParseMarkdownlintJSON(data)
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".yaml", ".yml":
+		return config.ParseMarkdownlintYAML(data)
+	default:
+		return config.ParseMarkdownlintJSON(data)
+	}
 }
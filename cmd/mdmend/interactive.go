@@ -0,0 +1,106 @@
+package main
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"github.com/mohitmishra786/mdmend/internal/config"
+	"github.com/mohitmishra786/mdmend/internal/fixer"
+)
+
+// prompter asks on the terminal whether each proposed change, one hunk of a
+// rule's fix at a time, should be applied.
+type prompter struct {
+	in        *bufio.Reader
+	out       io.Writer
+	acceptAll map[string]bool
+	quit      bool
+}
+
+func newPrompter(in io.Reader, out io.Writer) *prompter {
+	return &prompter{
+		in:        bufio.NewReader(in),
+		out:       out,
+		acceptAll: make(map[string]bool),
+	}
+}
+
+func (p *prompter) accept(c fixer.Change) bool {
+	id := c.Rule.ID()
+	if p.quit {
+		return false
+	}
+	if p.acceptAll[id] {
+		return true
+	}
+
+	_, _ = fmt.Fprintf(p.out, "\n%s %s (change %d of %d)\n", id, c.Rule.Description(), c.Hunk, c.Hunks)
+	for _, v := range c.Violations {
+		_, _ = fmt.Fprintf(p.out, "  line %d: %s\n", v.Line, v.Message)
+	}
+	_, _ = fmt.Fprint(p.out, c.Diff(2))
+
+	for {
+		_, _ = fmt.Fprintf(p.out, "Apply? [y]es, [N]o, [a]ll %s fixes, [q]uit: ", id)
+		line, err := p.in.ReadString('\n')
+		if err != nil && line == "" {
+			p.quit = true
+			return false
+		}
+		switch strings.ToLower(strings.TrimSpace(line)) {
+		case "y", "yes":
+			return true
+		case "", "n", "no":
+			return false
+		case "a", "all":
+			p.acceptAll[id] = true
+			return true
+		case "q", "quit":
+			p.quit = true
+			return false
+		}
+	}
+}
+
+// runFixInteractive offers each hunk of every rule's fix to each file for
+// review and writes only the accepted ones. Quitting keeps the changes
+// already accepted in the current file.
+func runFixInteractive(files []string, cfg *config.Config, opts *fixOptions) error {
+	p := newPrompter(os.Stdin, os.Stdout)
+	fixers := make(map[string]*fixer.Fixer)
+	filesChanged := 0
+	totalFixes := 0
+
+	for _, path := range files {
+		if p.quit {
+			break
+		}
+
+		content, err := os.ReadFile(path)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error reading %s: %v\n", path, err)
+			continue
+		}
+
+		f := fixerFor(fixers, cfg, path)
+		result := f.FixFunc(string(content), path, p.accept)
+		if !result.Changed {
+			continue
+		}
+		filesChanged++
+		totalFixes += result.Fixes
+
+		if opts.dryRun {
+			continue
+		}
+		if err := opts.write(path, content, result.Content); err != nil {
+			fmt.Fprintf(os.Stderr, "Error writing %s: %v\n", path, err)
+		}
+	}
+
+	fmt.Printf("\n%d files scanned — %d fixes accepted in %d file(s)\n", len(files), totalFixes, filesChanged)
+	return nil
+}
@@ -0,0 +1,167 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"os/signal"
+	"time"
+
+	"github.com/mohitmishra786/mdmend/internal/linkcheck"
+	"github.com/mohitmishra786/mdmend/internal/rules"
+	"github.com/spf13/cobra"
+)
+
+func newLinksCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "links",
+		Short: "Inspect links in Markdown files",
+	}
+
+	cmd.AddCommand(newLinksCheckCmd())
+	return cmd
+}
+
+type linksCheckOptions struct {
+	timeout      time.Duration
+	hostInterval time.Duration
+	cacheTTL     time.Duration
+	concurrency  int
+	retries      int
+	allow        []string
+	deny         []string
+}
+
+func newLinksCheckCmd() *cobra.Command {
+	var opts linksCheckOptions
+
+	cmd := &cobra.Command{
+		Use:   "check [paths...]",
+		Short: "Check that external links resolve",
+		Long: `Request every http and https URL in the given Markdown files and report
+the ones that do not resolve.
+
+Each URL is requested once with HEAD, falling back to GET when the server
+does not support HEAD. Requests to one host are spaced out, and network
+errors, 429 and 5xx responses are retried with backoff. Results are cached
+in the user cache directory for --cache-ttl; --no-cache ignores the cache.
+
+Flags default to the MD077 options in the config file.`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runLinksCheck(cmd, args, opts)
+		},
+	}
+
+	cmd.Flags().DurationVar(&opts.timeout, "timeout", linkcheck.DefaultTimeout, "Timeout for each request")
+	cmd.Flags().DurationVar(&opts.hostInterval, "host-interval", linkcheck.DefaultHostInterval, "Minimum time between requests to one host")
+	cmd.Flags().DurationVar(&opts.cacheTTL, "cache-ttl", linkcheck.DefaultCacheTTL, "How long results are cached")
+	cmd.Flags().IntVar(&opts.concurrency, "concurrency", linkcheck.DefaultConcurrency, "Number of requests in flight")
+	cmd.Flags().IntVar(&opts.retries, "retries", linkcheck.DefaultRetries, "Retries after network errors, 429 and 5xx responses")
+	cmd.Flags().StringSliceVar(&opts.allow, "allow", nil, "Only check URLs matching these patterns (host or URL, * wildcards)")
+	cmd.Flags().StringSliceVar(&opts.deny, "deny", nil, "Skip URLs matching these patterns (host or URL, * wildcards)")
+	return cmd
+}
+
+func runLinksCheck(cmd *cobra.Command, args []string, flags linksCheckOptions) error {
+	if len(args) == 0 {
+		args = []string{"."}
+	}
+
+	cfg, err := loadConfig(globalOpts)
+	if err != nil {
+		return err
+	}
+	rc := cfg.GetRuleConfig("MD077")
+	opts, err := rules.ExternalLinkOptions(rc)
+	if err != nil {
+		return err
+	}
+	ttl, err := rules.ExternalLinkCacheTTL(rc)
+	if err != nil {
+		return err
+	}
+
+	changed := cmd.Flags().Changed
+	if changed("timeout") {
+		opts.Timeout = flags.timeout
+	}
+	if changed("host-interval") {
+		opts.HostInterval = flags.hostInterval
+	}
+	if changed("cache-ttl") {
+		ttl = flags.cacheTTL
+	}
+	if changed("concurrency") {
+		opts.Concurrency = flags.concurrency
+	}
+	if changed("retries") {
+		opts.Retries = flags.retries
+	}
+	if changed("allow") {
+		opts.Allow = flags.allow
+	}
+	if changed("deny") {
+		opts.Deny = flags.deny
+	}
+	if !globalOpts.noCache {
+		cache, err := linkcheck.LoadCache("", ttl)
+		if err != nil {
+			return fmt.Errorf("loading link cache: %w", err)
+		}
+		opts.Cache = cache
+	}
+
+	walked, err := walkFiles(cfg, globalOpts, args)
+	if err != nil {
+		return err
+	}
+
+	links := make(map[string][]rules.ExternalLink)
+	var urls []string
+	for _, path := range walked.files {
+		content, err := os.ReadFile(path)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error reading %s: %v\n", path, err)
+			continue
+		}
+		links[path] = rules.ExternalLinks(string(content))
+		for _, l := range links[path] {
+			urls = append(urls, l.URL)
+		}
+	}
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+	defer stop()
+	checker := linkcheck.New(opts)
+	failed := make(map[string]linkcheck.Result)
+	checked, cached := 0, 0
+	for _, res := range checker.Check(ctx, urls) {
+		checked++
+		if res.Cached {
+			cached++
+		}
+		if !res.OK() {
+			failed[res.URL] = res
+		}
+	}
+
+	dead := 0
+	for _, path := range walked.files {
+		for _, l := range links[path] {
+			res, ok := failed[l.URL]
+			if !ok {
+				continue
+			}
+			dead++
+			if !globalOpts.quiet {
+				fmt.Printf("  %s:%d:%d  %s  %s\n", displayPath(path), l.Line, l.Column, res.Reason(), l.URL)
+			}
+		}
+	}
+
+	fmt.Printf("%d URL(s) checked (%d cached), %d dead link(s) in %d file(s)\n", checked, cached, dead, len(walked.files))
+	if dead > 0 {
+		os.Exit(1)
+	}
+	return nil
+}
@@ -6,16 +6,21 @@ import (
 	"os"
 	"runtime"
 	"sort"
+	"strconv"
 	"strings"
 	"time"
 
 	"github.com/fatih/color"
+	"github.com/mohitmishra786/mdmend/internal/baseline"
 	"github.com/mohitmishra786/mdmend/internal/cache"
 	"github.com/mohitmishra786/mdmend/internal/config"
 	"github.com/mohitmishra786/mdmend/internal/fixer"
+	"github.com/mohitmishra786/mdmend/internal/journal"
 	"github.com/mohitmishra786/mdmend/internal/linter"
+	"github.com/mohitmishra786/mdmend/internal/markdown"
 	"github.com/mohitmishra786/mdmend/internal/reporter"
 	"github.com/mohitmishra786/mdmend/internal/rules"
+	"github.com/mohitmishra786/mdmend/internal/vcs"
 	"github.com/mohitmishra786/mdmend/internal/walker"
 	"github.com/spf13/cobra"
 )
@@ -33,6 +38,7 @@ type globalOptions struct {
 	ignore        []string
 	rules         string
 	tabSize       int
+	lineLength    int
 	fenceStyle    string
 	urlStyle      string
 	fallbackLang  string
@@ -43,20 +49,43 @@ type globalOptions struct {
 	stats         bool
 	only          string
 	flavor        string
+	slugStyle     string
 	noCache       bool
 	watch         bool
+	set           []string
+	noIgnore      bool
+	gitignore     bool
+	stdinFilename string
+	followLinks   bool
+	maxDepth      int
+	maxFileSize   string
+	changed       bool
+	since         string
+	staged        bool
+	changedLines  bool
 }
 
 type fixOptions struct {
 	globalOptions
-	dryRun     bool
-	diff       bool
-	aggressive bool
-	workers    int
+	dryRun           bool
+	diff             bool
+	check            bool
+	interactive      bool
+	aggressive       bool
+	fixLevel         string
+	workers          int
+	allowInvalidUTF8 bool
+	backup           bool
+	journal          *journal.Journal
 }
 
 type lintOptions struct {
 	globalOptions
+	baselinePath  string
+	writeBaseline string
+	stdin         []byte
+	changes       *vcs.Changes
+	baseline      *baseline.Baseline
 }
 
 type suggestOptions struct {
@@ -105,11 +134,15 @@ func init() {
 	rootCmd.PersistentFlags().StringVarP(&globalOpts.config, "config", "c", "", "Path to config file (default: .mdmend.yml)")
 	rootCmd.PersistentFlags().StringVarP(&globalOpts.output, "output", "o", "console", "Output format: console|json|sarif")
 	rootCmd.PersistentFlags().StringVar(&globalOpts.flavor, "flavor", "", "Markdown flavor: standard|mdx|mkdocs")
+	rootCmd.PersistentFlags().StringVar(&globalOpts.slugStyle, "slug-style", "", "Heading anchor scheme: github|gitlab|mkdocs|docusaurus|hugo|pandoc (default: from flavor)")
 	rootCmd.PersistentFlags().BoolVar(&globalOpts.noCache, "no-cache", false, "Disable file hash cache")
 	rootCmd.PersistentFlags().BoolVar(&globalOpts.noColor, "no-color", false, "Disable color output")
 	rootCmd.PersistentFlags().StringArrayVar(&globalOpts.ignore, "ignore", []string{}, "Glob pattern to ignore (repeatable, e.g. --ignore vendor/)")
-	rootCmd.PersistentFlags().StringVar(&globalOpts.rules, "rules", "", "Enable/disable rules (comma-separated, prefix ~ to disable, e.g. MD040,~MD034)")
+	rootCmd.PersistentFlags().BoolVar(&globalOpts.noIgnore, "no-ignore", false, "Do not read .gitignore, .mdmendignore or .git/info/exclude")
+	rootCmd.PersistentFlags().BoolVar(&globalOpts.gitignore, "respect-gitignore", true, "Skip files excluded by .gitignore and .git/info/exclude")
+	rootCmd.PersistentFlags().StringVar(&globalOpts.rules, "rules", "", "Enable/disable rules by ID, alias, tag or wildcard, applied in order (prefix ~ to disable, = to reset to default, e.g. MD013,~no-bare-urls,=MD0*)")
 	rootCmd.PersistentFlags().IntVar(&globalOpts.tabSize, "tab-size", 4, "Tab size used by MD010 hard-tab check")
+	rootCmd.PersistentFlags().IntVar(&globalOpts.lineLength, "line-length", 120, "Line length limit for MD013 and its reflow fix")
 	rootCmd.PersistentFlags().StringVar(&globalOpts.fenceStyle, "fence-style", "backtick", "Code fence style for MD048: backtick|tilde")
 	rootCmd.PersistentFlags().StringVar(&globalOpts.urlStyle, "url-style", "angle", "URL wrap style for MD034: angle|link")
 	rootCmd.PersistentFlags().StringVar(&globalOpts.fallbackLang, "fallback-lang", "text", "Fallback language tag for MD040 when inference fails")
@@ -118,15 +151,29 @@ func init() {
 	rootCmd.PersistentFlags().BoolVar(&globalOpts.exitZero, "exit-zero", false, "Always exit with code 0 (advisory CI mode)")
 	rootCmd.PersistentFlags().IntVar(&globalOpts.maxViolations, "max-violations", 0, "Exit 1 only if violations exceed N (0 = any violation fails)")
 	rootCmd.PersistentFlags().BoolVar(&globalOpts.stats, "stats", false, "Print per-rule violation frequency table after summary")
-	rootCmd.PersistentFlags().StringVar(&globalOpts.only, "only", "", "Run only the given rules (comma-separated, e.g. MD040,MD034)")
+	rootCmd.PersistentFlags().StringArrayVar(&globalOpts.set, "set", []string{}, "Override a rule option (repeatable, e.g. --set MD013.line_length=100)")
+	rootCmd.PersistentFlags().BoolVar(&globalOpts.followLinks, "follow-symlinks", false, "Descend into symlinked directories (cycles are detected)")
+	rootCmd.PersistentFlags().IntVar(&globalOpts.maxDepth, "max-depth", 0, "Maximum directory depth to walk (0 = unlimited, 1 = top level only)")
+	rootCmd.PersistentFlags().StringVar(&globalOpts.maxFileSize, "max-file-size", "", "Skip files larger than this size, e.g. 1MB (overrides max_file_size)")
+	rootCmd.PersistentFlags().StringVar(&globalOpts.stdinFilename, "stdin-filename", "", "Path to report and resolve config for when reading from stdin with -")
+	rootCmd.PersistentFlags().BoolVar(&globalOpts.changed, "changed", false, "Only check Markdown files changed in git (working tree and untracked files)")
+	rootCmd.PersistentFlags().StringVar(&globalOpts.since, "since", "", "With --changed, compare against the merge base with this ref (e.g. origin/main)")
+	rootCmd.PersistentFlags().BoolVar(&globalOpts.staged, "staged", false, "Only check Markdown files staged in git")
+	rootCmd.PersistentFlags().BoolVar(&globalOpts.changedLines, "changed-lines", false, "Only report violations on lines changed in git (implies --changed unless --staged)")
+	rootCmd.PersistentFlags().StringVar(&globalOpts.only, "only", "", "Run only the given rules by ID, alias or tag (comma-separated, prefix ~ to exclude, e.g. links,~MD057)")
 
 	rootCmd.AddCommand(newCheckCmd())
 	rootCmd.AddCommand(newFixCmd())
 	rootCmd.AddCommand(newLintCmd())
 	rootCmd.AddCommand(newSuggestCmd())
 	rootCmd.AddCommand(newInitCmd())
+	rootCmd.AddCommand(newHookCmd())
+	rootCmd.AddCommand(newUndoCmd())
+	rootCmd.AddCommand(newConfigCmd())
 	rootCmd.AddCommand(newServerCmd())
 	rootCmd.AddCommand(newCacheCmd())
+	rootCmd.AddCommand(newLinksCmd())
+	rootCmd.AddCommand(newTOCCmd())
 	rootCmd.AddCommand(newVersionCmd())
 	rootCmd.AddCommand(newRulesCmd())
 }
@@ -185,10 +232,16 @@ Examples:
   mdmend fix README.md                 Fix a single file
   mdmend fix ./docs --dry-run          Preview only, do not write
   mdmend fix . --diff                  Show what would change as a diff
+  mdmend fix . --check                 Fail (exit 1) if any file would change
+  mdmend fix . --check --diff          Same, and show the diffs
   mdmend fix . --aggressive            Also apply heuristic fixes (MD040/MD034)
+  mdmend fix . --fix-level safe        Only apply whitespace and structure fixes
+  mdmend fix . --interactive           Review each change before applying it
   mdmend fix . --only MD009,MD010      Fix only specific rules
   mdmend fix . --workers 4             Use 4 parallel workers
-  mdmend fix . --output json           Output results as JSON`,
+  mdmend fix . --output json           Output results as JSON
+  mdmend fix . --backup                Keep originals for 'mdmend undo'
+  mdmend fix - < README.md             Fix stdin and write the result to stdout`,
 		Args: cobra.MinimumNArgs(0),
 		RunE: func(cmd *cobra.Command, args []string) error {
 			opts.globalOptions = globalOpts
@@ -198,8 +251,13 @@ Examples:
 
 	cmd.Flags().BoolVarP(&opts.dryRun, "dry-run", "n", false, "Show what would change; do not write files")
 	cmd.Flags().BoolVarP(&opts.diff, "diff", "d", false, "Output unified diffs instead of writing files")
+	cmd.Flags().BoolVar(&opts.check, "check", false, "List files that would change without writing them; exit 1 if any would")
 	cmd.Flags().BoolVar(&opts.aggressive, "aggressive", false, "Apply heuristic fixes (MD040/MD034) without confirmation")
+	cmd.Flags().StringVar(&opts.fixLevel, "fix-level", "", "Which fixes to apply: safe (whitespace and structure only) or unsafe (all, the default)")
+	cmd.Flags().BoolVarP(&opts.interactive, "interactive", "i", false, "Review each proposed change and accept or reject it")
 	cmd.Flags().IntVar(&opts.workers, "workers", runtime.NumCPU(), "Number of parallel worker goroutines")
+	cmd.Flags().BoolVar(&opts.allowInvalidUTF8, "allow-invalid-utf8", false, "Write fixes to files that are not valid UTF-8")
+	cmd.Flags().BoolVar(&opts.backup, "backup", false, "Keep the original files so 'mdmend undo' can restore them")
 
 	return cmd
 }
@@ -232,7 +290,13 @@ Examples:
   mdmend lint . --output json          Output as JSON (for CI/tooling)
   mdmend lint . --no-color             Plain text output
   mdmend lint . --exit-zero            Always exit 0 (advisory mode)
-  mdmend lint . --max-violations 10    Fail only when >10 violations found`,
+  mdmend lint . --max-violations 10    Fail only when >10 violations found
+  mdmend lint . --write-baseline .mdmend-baseline.json
+                                       Record existing violations
+  mdmend lint . --baseline .mdmend-baseline.json
+                                       Fail only on violations not in the baseline
+  mdmend lint - --stdin-filename docs/guide.md < guide.md
+                                       Lint stdin as if it were docs/guide.md`,
 		Args: cobra.MinimumNArgs(0),
 		RunE: func(cmd *cobra.Command, args []string) error {
 			opts.globalOptions = globalOpts
@@ -241,6 +305,8 @@ Examples:
 	}
 
 	cmd.Flags().BoolVar(&opts.watch, "watch", false, "Watch files and re-lint on changes")
+	cmd.Flags().StringVar(&opts.baselinePath, "baseline", "", "Suppress violations recorded in this baseline file (e.g. "+baseline.DefaultPath+")")
+	cmd.Flags().StringVar(&opts.writeBaseline, "write-baseline", "", "Record current violations to this baseline file and exit 0")
 
 	return cmd
 }
@@ -300,7 +366,9 @@ Examples:
   mdmend rules list
   mdmend rules list --fixable
   mdmend rules list --output json
-  mdmend rules info MD040`,
+  mdmend rules list --tag links
+  mdmend rules info MD040
+  mdmend rules info no-bare-urls`,
 	}
 
 	cmd.AddCommand(newRulesListCmd())
@@ -312,6 +380,7 @@ Examples:
 func newRulesListCmd() *cobra.Command {
 	var showFixable bool
 	var showUnfixable bool
+	var tag string
 
 	cmd := &cobra.Command{
 		Use:   "list",
@@ -322,14 +391,16 @@ Examples:
   mdmend rules list                  List all rules
   mdmend rules list --fixable        List only auto-fixable rules
   mdmend rules list --no-fixable     List only non-fixable (informational) rules
+  mdmend rules list --tag headings   List only rules with the given tag
   mdmend rules list --output json    Output as JSON`,
 		RunE: func(cmd *cobra.Command, args []string) error {
-			return runRulesList(showFixable, showUnfixable, globalOpts)
+			return runRulesList(showFixable, showUnfixable, tag, globalOpts)
 		},
 	}
 
 	cmd.Flags().BoolVar(&showFixable, "fixable", false, "Show only auto-fixable rules")
 	cmd.Flags().BoolVar(&showUnfixable, "no-fixable", false, "Show only non-fixable (informational) rules")
+	cmd.Flags().StringVar(&tag, "tag", "", "Show only rules with the given tag (e.g. links, headings)")
 
 	return cmd
 }
@@ -338,11 +409,11 @@ func newRulesInfoCmd() *cobra.Command {
 	return &cobra.Command{
 		Use:   "info <rule-id>",
 		Short: "Show details about a specific rule",
-		Long: `Show detailed information about a specific rule by its ID.
+		Long: `Show detailed information about a specific rule by its ID or alias.
 
 Examples:
   mdmend rules info MD040
-  mdmend rules info MD034
+  mdmend rules info no-bare-urls
   mdmend rules info MD009`,
 		Args: cobra.ExactArgs(1),
 		RunE: func(cmd *cobra.Command, args []string) error {
@@ -351,7 +422,7 @@ Examples:
 	}
 }
 
-func runRulesList(fixableOnly, unfixableOnly bool, opts globalOptions) error {
+func runRulesList(fixableOnly, unfixableOnly bool, tag string, opts globalOptions) error {
 	if opts.noColor {
 		color.NoColor = true
 	}
@@ -361,6 +432,19 @@ func runRulesList(fixableOnly, unfixableOnly bool, opts globalOptions) error {
 		return allRules[i].ID() < allRules[j].ID()
 	})
 
+	if tag != "" {
+		var tagged []rules.Rule
+		for _, r := range allRules {
+			if hasTag(r.ID(), tag) {
+				tagged = append(tagged, r)
+			}
+		}
+		if len(tagged) == 0 {
+			return fmt.Errorf("unknown tag %q — available tags: %s", tag, strings.Join(rules.AllTags(), ", "))
+		}
+		allRules = tagged
+	}
+
 	if opts.output == "json" {
 		return runRulesListJSON(allRules, fixableOnly, unfixableOnly)
 	}
@@ -404,15 +488,28 @@ func runRulesList(fixableOnly, unfixableOnly bool, opts globalOptions) error {
 		fmt.Printf(" (non-fixable only)")
 	}
 	fmt.Printf("\n\n")
+	fmt.Printf("  Tags: %s\n\n", strings.Join(rules.AllTags(), ", "))
 	return nil
 }
 
+func hasTag(id, tag string) bool {
+	for _, t := range rules.Tags(id) {
+		if strings.EqualFold(t, tag) {
+			return true
+		}
+	}
+	return false
+}
+
 func runRulesListJSON(allRules []rules.Rule, fixableOnly, unfixableOnly bool) error {
 	type ruleJSON struct {
-		ID          string `json:"id"`
-		Name        string `json:"name"`
-		Description string `json:"description"`
-		Fixable     bool   `json:"fixable"`
+		ID          string   `json:"id"`
+		Name        string   `json:"name"`
+		Description string   `json:"description"`
+		Fixable     bool     `json:"fixable"`
+		Aliases     []string `json:"aliases"`
+		Tags        []string `json:"tags"`
+		DocURL      string   `json:"doc_url"`
 	}
 
 	var out []ruleJSON
@@ -428,6 +525,9 @@ func runRulesListJSON(allRules []rules.Rule, fixableOnly, unfixableOnly bool) er
 			Name:        r.Name(),
 			Description: r.Description(),
 			Fixable:     r.Fixable(),
+			Aliases:     rules.Aliases(r.ID()),
+			Tags:        rules.Tags(r.ID()),
+			DocURL:      rules.DocURL(r.ID()),
 		})
 	}
 
@@ -444,8 +544,17 @@ func runRulesInfo(id string, opts globalOptions) error {
 		color.NoColor = true
 	}
 
-	id = strings.ToUpper(strings.TrimSpace(id))
-	r := rules.Get(id)
+	id = strings.TrimSpace(id)
+	r := rules.Get(strings.ToUpper(id))
+	if r == nil {
+		for _, candidate := range rules.Select(id) {
+			for _, alias := range rules.Aliases(candidate) {
+				if strings.EqualFold(alias, id) {
+					r = rules.Get(candidate)
+				}
+			}
+		}
+	}
 	if r == nil {
 		return fmt.Errorf("rule %q not found — run 'mdmend rules list' to see all available rules", id)
 	}
@@ -453,12 +562,19 @@ func runRulesInfo(id string, opts globalOptions) error {
 	if opts.output == "json" {
 		enc := json.NewEncoder(os.Stdout)
 		enc.SetIndent("", "  ")
-		return enc.Encode(map[string]interface{}{
+		info := map[string]interface{}{
 			"id":          r.ID(),
 			"name":        r.Name(),
 			"description": r.Description(),
 			"fixable":     r.Fixable(),
-		})
+			"aliases":     rules.Aliases(r.ID()),
+			"tags":        rules.Tags(r.ID()),
+			"doc_url":     rules.DocURL(r.ID()),
+		}
+		if r.Fixable() {
+			info["fix_level"] = rules.FixLevel(r.ID())
+		}
+		return enc.Encode(info)
 	}
 
 	cyan := color.New(color.FgCyan).SprintFunc()
@@ -466,7 +582,7 @@ func runRulesInfo(id string, opts globalOptions) error {
 	yellow := color.New(color.FgYellow).SprintFunc()
 	bold := color.New(color.Bold).SprintFunc()
 
-	fixableStr := green("yes — can be auto-fixed with 'mdmend fix'")
+	fixableStr := green(fmt.Sprintf("yes (%s) — can be auto-fixed with 'mdmend fix'", rules.FixLevel(r.ID())))
 	if !r.Fixable() {
 		fixableStr = yellow("no — informational only")
 	}
@@ -475,7 +591,9 @@ func runRulesInfo(id string, opts globalOptions) error {
 	fmt.Printf("  %s\n\n", strings.Repeat("─", 55))
 	fmt.Printf("  Description:  %s\n", r.Description())
 	fmt.Printf("  Fixable:      %s\n", fixableStr)
-	fmt.Printf("\n  Reference: https://github.com/DavidAnson/markdownlint/blob/main/doc/%s.md\n\n", r.ID())
+	fmt.Printf("  Aliases:      %s\n", strings.Join(rules.Aliases(r.ID()), ", "))
+	fmt.Printf("  Tags:         %s\n", strings.Join(rules.Tags(r.ID()), ", "))
+	fmt.Printf("\n  Reference: %s\n\n", rules.DocURL(r.ID()))
 
 	return nil
 }
@@ -494,34 +612,57 @@ func runFix(args []string, opts *fixOptions) error {
 		return err
 	}
 	cfg.Aggressive = opts.aggressive
+	if opts.fixLevel != "" {
+		if _, err := config.ParseFixLevel(opts.fixLevel); err != nil {
+			return err
+		}
+		cfg.FixLevel = opts.fixLevel
+	}
 
-	ignore := append(cfg.Ignore, opts.ignore...)
-	w := walker.New(ignore)
+	stdin, err := isStdinArgs(args)
+	if err != nil {
+		return err
+	}
+	if opts.interactive && (stdin || opts.check || opts.diff || opts.output == "json") {
+		return fmt.Errorf("--interactive cannot be combined with stdin input, --check, --diff or --output json")
+	}
+	if stdin {
+		return runFixStdin(cfg, opts)
+	}
 
 	start := time.Now()
-	files, err := w.Walk(args)
+	walked, err := walkFiles(cfg, opts.globalOptions, args, walker.WithAllowInvalidUTF8(opts.allowInvalidUTF8))
 	if err != nil {
 		return err
 	}
+	files := walked.files
+	reportDiscovery(walked, time.Since(start), opts.globalOptions)
 
 	if len(files) == 0 {
 		fmt.Println("No Markdown files found.")
 		return nil
 	}
 
-	if opts.verbose && !opts.quiet {
-		fmt.Printf("\n  Discovered %d file(s) in %s\n", len(files), time.Since(start).Round(time.Millisecond))
-		for _, f := range files {
-			fmt.Printf("    %s\n", f)
-		}
-		fmt.Println()
+	if opts.check {
+		return runFixCheck(files, cfg, opts)
 	}
 
-	if opts.output == "json" {
-		return runFixJSON(files, cfg, opts)
+	if err := opts.startBackup(); err != nil {
+		return err
 	}
 
-	return runFixConsole(files, cfg, opts)
+	switch {
+	case opts.interactive:
+		err = runFixInteractive(files, cfg, opts)
+	case opts.output == "json":
+		err = runFixJSON(files, cfg, opts)
+	default:
+		err = runFixConsole(files, cfg, opts)
+	}
+	if saveErr := opts.saveBackup(); saveErr != nil && err == nil {
+		err = saveErr
+	}
+	return err
 }
 
 func runFixConsole(files []string, cfg *config.Config, opts *fixOptions) error {
@@ -530,7 +671,7 @@ func runFixConsole(files []string, cfg *config.Config, opts *fixOptions) error {
 		cr.PrintHeader(version, len(files), opts.workers)
 	}
 
-	f := fixer.New(cfg)
+	fixers := make(map[string]*fixer.Fixer)
 	totalViolations := 0
 	filesChanged := 0
 	ruleStats := make(map[string]int)
@@ -544,6 +685,8 @@ func runFixConsole(files []string, cfg *config.Config, opts *fixOptions) error {
 			continue
 		}
 
+		f := fixerFor(fixers, cfg, path)
+
 		violations := f.Lint(string(content), path)
 		violations = applyOnlyFilter(violations, opts.only)
 
@@ -571,7 +714,7 @@ func runFixConsole(files []string, cfg *config.Config, opts *fixOptions) error {
 				filesChanged++
 
 				if !opts.dryRun {
-					if err := fixer.AtomicWrite(path, []byte(result.Content)); err != nil {
+					if err := opts.write(path, content, result.Content); err != nil {
 						fmt.Fprintf(os.Stderr, "Error writing %s: %v\n", path, err)
 						continue
 					}
@@ -609,7 +752,7 @@ func runFixConsole(files []string, cfg *config.Config, opts *fixOptions) error {
 }
 
 func runFixJSON(files []string, cfg *config.Config, opts *fixOptions) error {
-	f := fixer.New(cfg)
+	fixers := make(map[string]*fixer.Fixer)
 	jr := reporter.NewJSONReporter()
 
 	var results []reporter.JSONFileResult
@@ -626,6 +769,8 @@ func runFixJSON(files []string, cfg *config.Config, opts *fixOptions) error {
 			continue
 		}
 
+		f := fixerFor(fixers, cfg, path)
+
 		result := f.Fix(string(content), path)
 		violations := reporter.ConvertViolations(f.Lint(string(content), path))
 		violations = applyOnlyFilterJSON(violations, opts.only)
@@ -636,6 +781,7 @@ func runFixJSON(files []string, cfg *config.Config, opts *fixOptions) error {
 		}
 		if result.Changed {
 			fileResult.Fixed = result.Fixes
+			fileResult.WouldChange = opts.dryRun
 		}
 		results = append(results, fileResult)
 
@@ -645,7 +791,7 @@ func runFixJSON(files []string, cfg *config.Config, opts *fixOptions) error {
 		totalViolations += len(violations)
 
 		if result.Changed && !opts.dryRun {
-			if err := fixer.AtomicWrite(path, []byte(result.Content)); err != nil {
+			if err := opts.write(path, content, result.Content); err != nil {
 				fmt.Fprintf(os.Stderr, "Error writing %s: %v\n", path, err)
 			}
 		}
@@ -671,33 +817,43 @@ func runLint(args []string, opts *lintOptions) error {
 	if err != nil {
 		return err
 	}
+	if err := loadBaseline(opts); err != nil {
+		return err
+	}
+
+	stdin, err := isStdinArgs(args)
+	if err != nil {
+		return err
+	}
+	if stdin {
+		return runLintStdin(cfg, opts)
+	}
 
 	if opts.watch {
 		return runLintWatch(args, opts)
 	}
 
-	ignore := append(cfg.Ignore, opts.ignore...)
-	w := walker.New(ignore)
-
 	start := time.Now()
-	files, err := w.Walk(args)
+	walked, err := walkFiles(cfg, opts.globalOptions, args)
 	if err != nil {
 		return err
 	}
+	files := walked.files
+	reportDiscovery(walked, time.Since(start), opts.globalOptions)
+	opts.changes = walked.changes
+	if opts.changedLines {
+		opts.noCache = true
+	}
+	// MD077 results depend on the network as well as the file content.
+	if cfg.IsEnabled("MD077") {
+		opts.noCache = true
+	}
 
 	if len(files) == 0 {
 		fmt.Println("No Markdown files found.")
 		return nil
 	}
 
-	if opts.verbose && !opts.quiet {
-		fmt.Printf("\n  Discovered %d file(s) in %s\n", len(files), time.Since(start).Round(time.Millisecond))
-		for _, f := range files {
-			fmt.Printf("    %s\n", f)
-		}
-		fmt.Println()
-	}
-
 	switch opts.output {
 	case "json":
 		return runLintJSON(files, cfg, opts)
@@ -726,7 +882,7 @@ func runLintConsole(files []string, cfg *config.Config, opts *lintOptions) error
 	for _, path := range files {
 		fileStart := time.Now()
 
-		content, err := os.ReadFile(path)
+		content, err := opts.readInput(path)
 		if err != nil {
 			fmt.Fprintf(os.Stderr, "Error reading %s: %v\n", path, err)
 			continue
@@ -746,6 +902,8 @@ func runLintConsole(files []string, cfg *config.Config, opts *lintOptions) error
 		l := linter.New(fileCfg)
 		result := l.Lint(string(content), path)
 		filtered := applyOnlyFilter(result.Violations, opts.only)
+		filtered = applyChangedLinesFilter(filtered, path, opts)
+		filtered = applyBaseline(filtered, path, content, opts)
 
 		if lintCache != nil {
 			_ = lintCache.Update(path, content, len(filtered))
@@ -794,7 +952,7 @@ func runLintConsole(files []string, cfg *config.Config, opts *lintOptions) error
 		_ = lintCache.Save()
 	}
 
-	return exitForViolations(totalViolations, opts)
+	return finishLint(totalViolations, opts)
 }
 
 func runLintJSON(files []string, cfg *config.Config, opts *lintOptions) error {
@@ -806,7 +964,7 @@ func runLintJSON(files []string, cfg *config.Config, opts *lintOptions) error {
 	fixable := 0
 
 	for _, path := range files {
-		content, err := os.ReadFile(path)
+		content, err := opts.readInput(path)
 		if err != nil {
 			results = append(results, reporter.JSONFileResult{
 				Path:  path,
@@ -818,8 +976,10 @@ func runLintJSON(files []string, cfg *config.Config, opts *lintOptions) error {
 		fileCfg := config.ApplyFlavor(cfg, path)
 		l := linter.New(fileCfg)
 		result := l.Lint(string(content), path)
-		violations := reporter.ConvertViolations(result.Violations)
-		violations = applyOnlyFilterJSON(violations, opts.only)
+		filtered := applyOnlyFilter(result.Violations, opts.only)
+		filtered = applyChangedLinesFilter(filtered, path, opts)
+		filtered = applyBaseline(filtered, path, content, opts)
+		violations := reporter.ConvertViolations(filtered)
 
 		results = append(results, reporter.JSONFileResult{
 			Path:       path,
@@ -848,7 +1008,7 @@ func runLintJSON(files []string, cfg *config.Config, opts *lintOptions) error {
 		return err
 	}
 
-	return exitForViolations(totalViolations, opts)
+	return finishLint(totalViolations, opts)
 }
 
 func runLintSARIF(files []string, cfg *config.Config, opts *lintOptions) error {
@@ -860,7 +1020,7 @@ func runLintSARIF(files []string, cfg *config.Config, opts *lintOptions) error {
 	fixable := 0
 
 	for _, path := range files {
-		content, err := os.ReadFile(path)
+		content, err := opts.readInput(path)
 		if err != nil {
 			results = append(results, reporter.JSONFileResult{
 				Path:  path,
@@ -872,8 +1032,10 @@ func runLintSARIF(files []string, cfg *config.Config, opts *lintOptions) error {
 		fileCfg := config.ApplyFlavor(cfg, path)
 		l := linter.New(fileCfg)
 		result := l.Lint(string(content), path)
-		violations := reporter.ConvertViolations(result.Violations)
-		violations = applyOnlyFilterJSON(violations, opts.only)
+		filtered := applyOnlyFilter(result.Violations, opts.only)
+		filtered = applyChangedLinesFilter(filtered, path, opts)
+		filtered = applyBaseline(filtered, path, content, opts)
+		violations := reporter.ConvertViolations(filtered)
 
 		results = append(results, reporter.JSONFileResult{
 			Path:       path,
@@ -902,7 +1064,7 @@ func runLintSARIF(files []string, cfg *config.Config, opts *lintOptions) error {
 		return err
 	}
 
-	return exitForViolations(totalViolations, opts)
+	return finishLint(totalViolations, opts)
 }
 
 func exitForViolations(totalViolations int, opts *lintOptions) error {
@@ -939,8 +1101,7 @@ func runSuggest(args []string, opts *suggestOptions) error {
 	}
 	cfg.Aggressive = true
 
-	ignore := append(cfg.Ignore, opts.ignore...)
-	w := walker.New(ignore)
+	w := newWalker(cfg, opts.globalOptions)
 	files, err := w.Walk(args)
 	if err != nil {
 		return err
@@ -956,7 +1117,7 @@ func runSuggest(args []string, opts *suggestOptions) error {
 		cr.PrintHeader(version, len(files), 1)
 	}
 
-	f := fixer.New(cfg)
+	fixers := make(map[string]*fixer.Fixer)
 	dr := reporter.NewDiffReporter()
 	changed := 0
 
@@ -967,6 +1128,8 @@ func runSuggest(args []string, opts *suggestOptions) error {
 			continue
 		}
 
+		f := fixerFor(fixers, cfg, path)
+
 		result := f.Fix(string(content), path)
 		if result.Changed {
 			changed++
@@ -998,31 +1161,102 @@ func loadConfig(opts globalOptions) (*config.Config, error) {
 		}
 		cfg.Flavor = config.NormalizeFlavor(opts.flavor)
 	}
+	if opts.slugStyle != "" {
+		cfg.SlugStyle = opts.slugStyle
+	}
+	if _, err := config.ParseSlugStyle(cfg.SlugStyle); err != nil {
+		return nil, err
+	}
 
-	if opts.tabSize > 0 {
-		cfg.TabSize = opts.tabSize
+	if err := config.ApplyEnvOverrides(cfg, os.Environ()); err != nil {
+		return nil, err
 	}
 
-	if opts.rules != "" {
-		parts := strings.Split(opts.rules, ",")
-		for _, r := range parts {
-			r = strings.TrimSpace(r)
-			if len(r) == 0 {
-				continue
-			}
-			if r[0] == '~' {
-				cfg.Disable = append(cfg.Disable, strings.ToUpper(r[1:]))
-			}
+	flags := rootCmd.PersistentFlags()
+	if flags.Changed("tab-size") && opts.tabSize > 0 {
+		cfg.TabSize = opts.tabSize
+	}
+	if flags.Changed("follow-symlinks") {
+		cfg.FollowSymlinks = opts.followLinks
+	}
+	if flags.Changed("max-depth") {
+		cfg.MaxDepth = opts.maxDepth
+	}
+	if flags.Changed("max-file-size") {
+		cfg.MaxFileSize = opts.maxFileSize
+	}
+	if _, err := cfg.GetMaxFileSize(); err != nil {
+		return nil, err
+	}
+	if _, err := markdown.ParseEndOfLine(cfg.EndOfLine); err != nil {
+		return nil, err
+	}
+	if _, err := config.ParseFixLevel(cfg.FixLevel); err != nil {
+		return nil, err
+	}
+	legacy := []struct {
+		flag, rule, key, value string
+	}{
+		{"line-length", "MD013", "line_length", strconv.Itoa(opts.lineLength)},
+		{"fence-style", "MD048", "style", opts.fenceStyle},
+		{"url-style", "MD034", "style", opts.urlStyle},
+		{"fallback-lang", "MD040", "fallback", opts.fallbackLang},
+	}
+	for _, l := range legacy {
+		if !flags.Changed(l.flag) {
+			continue
+		}
+		if err := config.SetRuleOption(cfg, l.rule, l.key, l.value); err != nil {
+			return nil, fmt.Errorf("--%s: %w", l.flag, err)
 		}
 	}
 
+	if err := config.ApplyOverrides(cfg, opts.set); err != nil {
+		return nil, fmt.Errorf("--set: %w", err)
+	}
+
+	if err := validateRuleSelectors("rules", opts.rules); err != nil {
+		return nil, err
+	}
+	if err := validateRuleSelectors("only", opts.only); err != nil {
+		return nil, err
+	}
+
 	if opts.only != "" {
 		cfg.Only = parseRuleList(opts.only)
 	}
+	cfg.Select = append(cfg.Select, parseRuleList(opts.rules)...)
 
 	return cfg, nil
 }
 
+// fixerFor returns a fixer for path's resolved flavor, creating one per
+// flavor on first use.
+func fixerFor(fixers map[string]*fixer.Fixer, cfg *config.Config, path string) *fixer.Fixer {
+	flavor := config.ResolveFlavor(cfg, path)
+	f, ok := fixers[flavor]
+	if !ok {
+		f = fixer.New(config.ApplyFlavor(cfg, path))
+		fixers[flavor] = f
+	}
+	return f
+}
+
+func newWalker(cfg *config.Config, opts globalOptions, extra ...walker.Option) *walker.Walker {
+	ignore := append(append([]string{}, cfg.Ignore...), opts.ignore...)
+	maxFileSize, _ := cfg.GetMaxFileSize()
+	walkerOpts := append([]walker.Option{
+		walker.WithExtensions(cfg.GetExtensions()...),
+		walker.WithInclude(cfg.Include...),
+		walker.WithIgnoreFiles(!opts.noIgnore),
+		walker.WithGitignore(opts.gitignore),
+		walker.WithFollowSymlinks(cfg.FollowSymlinks),
+		walker.WithMaxDepth(cfg.MaxDepth),
+		walker.WithMaxFileSize(maxFileSize),
+	}, extra...)
+	return walker.New(ignore, walkerOpts...)
+}
+
 func parseRuleList(value string) []string {
 	var ids []string
 	for _, r := range strings.Split(value, ",") {
@@ -1034,14 +1268,26 @@ func parseRuleList(value string) []string {
 	return ids
 }
 
+// validateRuleSelectors rejects --rules/--only entries that match no rule ID,
+// alias or tag, so typos do not silently select nothing.
+func validateRuleSelectors(flag, value string) error {
+	for _, expr := range parseRuleList(value) {
+		_, sel := config.ParseSelection(expr)
+		if len(rules.Select(sel)) == 0 {
+			return fmt.Errorf("--%s: unknown rule, alias or tag %q", flag, strings.ToLower(sel))
+		}
+	}
+	return nil
+}
+
 func applyOnlyFilter(violations []rules.Violation, only string) []rules.Violation {
 	if only == "" {
 		return violations
 	}
-	allowed := parseRuleSet(only)
+	selection := &config.Config{Only: parseRuleList(only)}
 	var filtered []rules.Violation
 	for _, v := range violations {
-		if allowed[strings.ToUpper(v.Rule)] {
+		if selection.IsEnabled(v.Rule) {
 			filtered = append(filtered, v)
 		}
 	}
@@ -1052,27 +1298,16 @@ func applyOnlyFilterJSON(violations []reporter.JSONViolation, only string) []rep
 	if only == "" {
 		return violations
 	}
-	allowed := parseRuleSet(only)
+	selection := &config.Config{Only: parseRuleList(only)}
 	var filtered []reporter.JSONViolation
 	for _, v := range violations {
-		if allowed[strings.ToUpper(v.Rule)] {
+		if selection.IsEnabled(v.Rule) {
 			filtered = append(filtered, v)
 		}
 	}
 	return filtered
 }
 
-func parseRuleSet(s string) map[string]bool {
-	m := make(map[string]bool)
-	for _, r := range strings.Split(s, ",") {
-		r = strings.TrimSpace(r)
-		if r != "" {
-			m[strings.ToUpper(r)] = true
-		}
-	}
-	return m
-}
-
 func printRuleStats(ruleStats map[string]int, noColor bool) {
 	type ruleStat struct {
 		id    string
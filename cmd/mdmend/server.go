@@ -25,8 +25,8 @@ func newServerCmd() *cobra.Command {
 		Short: "Start the mdmend language server (stdio JSON-RPC)",
 		Long: `Start a minimal Language Server Protocol server over stdio.
 
-Supports initialize, textDocument/didOpen, and publishDiagnostics for
-editor integration.
+Supports initialize, textDocument/didOpen, publishDiagnostics and quick
+fixes (textDocument/codeAction) for editor integration.
 
 Examples:
   mdmend server
@@ -100,6 +100,27 @@ type lspPosition struct {
 	Character int `json:"character"`
 }
 
+type codeActionParams struct {
+	TextDocument struct {
+		URI string `json:"uri"`
+	} `json:"textDocument"`
+	Range lspRange `json:"range"`
+}
+
+type lspTextEdit struct {
+	Range   lspRange `json:"range"`
+	NewText string   `json:"newText"`
+}
+
+type lspCodeAction struct {
+	Title       string          `json:"title"`
+	Kind        string          `json:"kind"`
+	Diagnostics []lspDiagnostic `json:"diagnostics"`
+	Edit        struct {
+		Changes map[string][]lspTextEdit `json:"changes"`
+	} `json:"edit"`
+}
+
 func runServer(opts *serverOptions) error {
 	cfg, err := loadConfig(opts.globalOptions)
 	if err != nil {
@@ -111,6 +132,10 @@ func runServer(opts *serverOptions) error {
 	reader := bufio.NewReader(os.Stdin)
 	writer := os.Stdout
 
+	// The text and last published violations of each document, for quick
+	// fixes.
+	published := make(map[string]lspDocument)
+
 	for {
 		body, err := readLSPMessage(reader)
 		if err != nil {
@@ -129,7 +154,8 @@ func runServer(opts *serverOptions) error {
 		case "initialize":
 			result := map[string]interface{}{
 				"capabilities": map[string]interface{}{
-					"textDocumentSync": 1,
+					"textDocumentSync":   1,
+					"codeActionProvider": true,
 				},
 				"serverInfo": map[string]string{
 					"name":    "mdmend",
@@ -154,7 +180,9 @@ func runServer(opts *serverOptions) error {
 			}
 			path := uriToPath(params.TextDocument.URI)
 			result := client.LintString(params.TextDocument.Text, path)
-			if err := publishDiagnostics(writer, params.TextDocument.URI, result.Violations); err != nil {
+			doc := newLSPDocument(params.TextDocument.Text, result.Violations)
+			published[params.TextDocument.URI] = doc
+			if err := publishDiagnostics(writer, params.TextDocument.URI, doc); err != nil {
 				return err
 			}
 		case "textDocument/didChange":
@@ -173,8 +201,31 @@ func runServer(opts *serverOptions) error {
 				continue
 			}
 			path := uriToPath(params.TextDocument.URI)
-			result := client.LintString(params.ContentChanges[len(params.ContentChanges)-1].Text, path)
-			if err := publishDiagnostics(writer, params.TextDocument.URI, result.Violations); err != nil {
+			text := params.ContentChanges[len(params.ContentChanges)-1].Text
+			result := client.LintString(text, path)
+			doc := newLSPDocument(text, result.Violations)
+			published[params.TextDocument.URI] = doc
+			if err := publishDiagnostics(writer, params.TextDocument.URI, doc); err != nil {
+				return err
+			}
+		case "textDocument/didClose":
+			var params struct {
+				TextDocument struct {
+					URI string `json:"uri"`
+				} `json:"textDocument"`
+			}
+			if err := json.Unmarshal(req.Params, &params); err != nil {
+				continue
+			}
+			delete(published, params.TextDocument.URI)
+		case "textDocument/codeAction":
+			var params codeActionParams
+			if err := json.Unmarshal(req.Params, &params); err != nil {
+				continue
+			}
+			uri := params.TextDocument.URI
+			actions := codeActions(uri, params.Range, published[uri])
+			if err := writeLSPResponse(writer, req.ID, actions); err != nil {
 				return err
 			}
 		default:
@@ -234,31 +285,21 @@ func writeLSPResponse(writer io.Writer, id json.RawMessage, result interface{})
 	return writeLSPMessage(writer, data)
 }
 
-func publishDiagnostics(writer io.Writer, uri string, violations []mdmend.Violation) error {
-	diagnostics := make([]lspDiagnostic, 0, len(violations))
-	for _, v := range violations {
-		severity := 2
-		if v.Fixable {
-			severity = 3
-		}
-		line := v.Line - 1
-		if line < 0 {
-			line = 0
-		}
-		column := v.Column - 1
-		if column < 0 {
-			column = 0
-		}
-		diagnostics = append(diagnostics, lspDiagnostic{
-			Range: lspRange{
-				Start: lspPosition{Line: line, Character: column},
-				End:   lspPosition{Line: line, Character: column + 1},
-			},
-			Severity: severity,
-			Code:     v.Rule,
-			Source:   "mdmend",
-			Message:  v.Message,
-		})
+// lspDocument is the text of an open document, split into lines, and the
+// violations last published for it.
+type lspDocument struct {
+	lines      []string
+	violations []mdmend.Violation
+}
+
+func newLSPDocument(text string, violations []mdmend.Violation) lspDocument {
+	return lspDocument{lines: strings.Split(text, "\n"), violations: violations}
+}
+
+func publishDiagnostics(writer io.Writer, uri string, doc lspDocument) error {
+	diagnostics := make([]lspDiagnostic, 0, len(doc.violations))
+	for _, v := range doc.violations {
+		diagnostics = append(diagnostics, toDiagnostic(v, doc.lines))
 	}
 
 	notification := jsonRPCNotification{
@@ -284,6 +325,92 @@ func publishDiagnostics(writer io.Writer, uri string, violations []mdmend.Violat
 	return writeLSPMessage(writer, data)
 }
 
+// toDiagnostic converts v, whose columns are byte offsets into lines, to a
+// diagnostic whose range counts UTF-16 code units, as LSP positions do.
+func toDiagnostic(v mdmend.Violation, lines []string) lspDiagnostic {
+	severity := 2
+	if v.Fixable {
+		severity = 3
+	}
+	switch v.Severity {
+	case "error":
+		severity = 1
+	case "warning":
+		severity = 2
+	case "info":
+		severity = 3
+	}
+	line := v.Line - 1
+	if line < 0 {
+		line = 0
+	}
+	column := v.Column - 1
+	if column < 0 {
+		column = 0
+	}
+	end := column + 1
+	if v.EndColumn > v.Column {
+		end = v.EndColumn - 1
+	}
+	if line < len(lines) {
+		column, end = utf16Offset(lines[line], column), utf16Offset(lines[line], end)
+	}
+	return lspDiagnostic{
+		Range: lspRange{
+			Start: lspPosition{Line: line, Character: column},
+			End:   lspPosition{Line: line, Character: end},
+		},
+		Severity: severity,
+		Code:     v.Rule,
+		Source:   "mdmend",
+		Message:  v.Message,
+	}
+}
+
+// utf16Offset converts a byte offset into line to UTF-16 code units. An
+// offset past the end of the line counts one unit per byte.
+func utf16Offset(line string, offset int) int {
+	extra := 0
+	if offset > len(line) {
+		extra = offset - len(line)
+		offset = len(line)
+	}
+	n := 0
+	for _, r := range line[:offset] {
+		if r >= 0x10000 {
+			n += 2
+		} else {
+			n++
+		}
+	}
+	return n + extra
+}
+
+// codeActions offers a quick fix for every published violation on the lines
+// of r whose Suggested text replaces a known range.
+func codeActions(uri string, r lspRange, doc lspDocument) []lspCodeAction {
+	actions := []lspCodeAction{}
+	for _, v := range doc.violations {
+		if v.Suggested == "" || v.EndColumn <= v.Column {
+			continue
+		}
+		d := toDiagnostic(v, doc.lines)
+		if d.Range.Start.Line < r.Start.Line || d.Range.Start.Line > r.End.Line {
+			continue
+		}
+		action := lspCodeAction{
+			Title:       fmt.Sprintf("Replace with %q", v.Suggested),
+			Kind:        "quickfix",
+			Diagnostics: []lspDiagnostic{d},
+		}
+		action.Edit.Changes = map[string][]lspTextEdit{
+			uri: {{Range: d.Range, NewText: v.Suggested}},
+		}
+		actions = append(actions, action)
+	}
+	return actions
+}
+
 func uriToPath(uri string) string {
 	if strings.HasPrefix(uri, "file://") {
 		path := strings.TrimPrefix(uri, "file://")
@@ -327,14 +454,42 @@ func fromInternalConfig(cfg *config.Config) *mdmend.Config {
 			Tables:                rc.Tables,
 			Level:                 rc.Level,
 			SuggestDemotion:       rc.SuggestDemotion,
+			Fix:                   rc.Fix,
+			Vocabulary:            rc.Vocabulary,
+			Dictionaries:          rc.Dictionaries,
+			Words:                 rc.Words,
+			ProhibitedTexts:       rc.ProhibitedTexts,
+			Languages:             rc.Languages,
+			Reflow:                rc.Reflow,
+			SmallWords:            rc.SmallWords,
+			Allow:                 rc.Allow,
+			Deny:                  rc.Deny,
+			Timeout:               rc.Timeout,
+			HostInterval:          rc.HostInterval,
+			CacheTTL:              rc.CacheTTL,
+			Concurrency:           rc.Concurrency,
+			Retries:               rc.Retries,
+			MaxLevel:              rc.MaxLevel,
+			Insert:                rc.Insert,
 		}
 	}
 
 	return &mdmend.Config{
-		Disable:    cfg.Disable,
-		Rules:      rules,
-		Ignore:     cfg.Ignore,
-		TabSize:    cfg.TabSize,
-		Aggressive: cfg.Aggressive,
+		Disable:        cfg.Disable,
+		Enable:         cfg.Enable,
+		Only:           cfg.Only,
+		Select:         cfg.Select,
+		Rules:          rules,
+		Ignore:         cfg.Ignore,
+		Extensions:     cfg.Extensions,
+		Include:        cfg.Include,
+		MaxFileSize:    cfg.MaxFileSize,
+		MaxDepth:       cfg.MaxDepth,
+		FollowSymlinks: cfg.FollowSymlinks,
+		EndOfLine:      cfg.EndOfLine,
+		TabSize:        cfg.TabSize,
+		Aggressive:     cfg.Aggressive,
+		FixLevel:       cfg.FixLevel,
+		SlugStyle:      cfg.SlugStyle,
 	}
 }
@@ -0,0 +1,39 @@
+package main
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/mohitmishra786/mdmend/pkg/mdmend"
+)
+
+func TestCodeActionsUTF16(t *testing.T) {
+	text := "# Title\n\nCafé — teh menu 😀 teh end\n"
+	line := strings.Split(text, "\n")[2]
+	var violations []mdmend.Violation
+	for _, start := range []int{strings.Index(line, "teh"), strings.LastIndex(line, "teh")} {
+		violations = append(violations, mdmend.Violation{
+			Rule:      "MD075",
+			Line:      3,
+			Column:    start + 1,
+			EndColumn: start + 4,
+			Message:   `Unknown word "teh"`,
+			Suggested: "the",
+		})
+	}
+
+	doc := newLSPDocument(text, violations)
+	actions := codeActions("file:///doc.md", lspRange{Start: lspPosition{Line: 2}, End: lspPosition{Line: 2}}, doc)
+	if len(actions) != 2 {
+		t.Fatalf("got %d actions, want 2", len(actions))
+	}
+	for i, want := range []lspRange{
+		{Start: lspPosition{Line: 2, Character: 7}, End: lspPosition{Line: 2, Character: 10}},
+		{Start: lspPosition{Line: 2, Character: 19}, End: lspPosition{Line: 2, Character: 22}},
+	} {
+		edit := actions[i].Edit.Changes["file:///doc.md"][0]
+		if edit.Range != want || actions[i].Diagnostics[0].Range != want {
+			t.Errorf("action %d range = %+v, want %+v", i, edit.Range, want)
+		}
+	}
+}
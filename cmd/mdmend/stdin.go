@@ -0,0 +1,110 @@
+package main
+
+import (
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/fatih/color"
+	"github.com/mohitmishra786/mdmend/internal/config"
+	"github.com/mohitmishra786/mdmend/internal/fixer"
+	"github.com/mohitmishra786/mdmend/internal/reporter"
+)
+
+const (
+	stdinArg  = "-"
+	stdinName = "<stdin>"
+)
+
+// isStdinArgs reports whether args ask for the document on stdin. "-" cannot
+// be combined with other paths.
+func isStdinArgs(args []string) (bool, error) {
+	for _, arg := range args {
+		if arg == stdinArg {
+			if len(args) > 1 {
+				return false, fmt.Errorf("cannot combine %q with other paths", stdinArg)
+			}
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+// stdinPath is the name stdin content is reported under. --stdin-filename
+// also drives per-file flavor resolution and relative link checks.
+func (o globalOptions) stdinPath() string {
+	if o.stdinFilename != "" {
+		return o.stdinFilename
+	}
+	return stdinName
+}
+
+func (o *lintOptions) readInput(path string) ([]byte, error) {
+	if o.stdin != nil {
+		return o.stdin, nil
+	}
+	return os.ReadFile(path)
+}
+
+func runLintStdin(cfg *config.Config, opts *lintOptions) error {
+	content, err := io.ReadAll(os.Stdin)
+	if err != nil {
+		return fmt.Errorf("reading stdin: %w", err)
+	}
+	opts.stdin = content
+	opts.noCache = true
+
+	files := []string{opts.stdinPath()}
+	switch opts.output {
+	case "json":
+		return runLintJSON(files, cfg, opts)
+	case "sarif":
+		return runLintSARIF(files, cfg, opts)
+	default:
+		return runLintConsole(files, cfg, opts)
+	}
+}
+
+// runFixStdin fixes the document on stdin and writes the result to stdout,
+// or a unified diff with --diff. Nothing else is written to stdout so the
+// command can be used as an editor formatter. With --check only the optional
+// diff is written, and the exit code is 1 if the document would change.
+func runFixStdin(cfg *config.Config, opts *fixOptions) error {
+	if opts.noColor {
+		color.NoColor = true
+	}
+
+	content, err := io.ReadAll(os.Stdin)
+	if err != nil {
+		return fmt.Errorf("reading stdin: %w", err)
+	}
+
+	path := opts.stdinPath()
+	f := fixer.New(config.ApplyFlavor(cfg, path))
+	result := f.Fix(string(content), path)
+
+	if opts.check {
+		if !result.Changed {
+			return nil
+		}
+		if opts.diff {
+			if err := reporter.NewDiffReporter().Diff(path, string(content), result.Content); err != nil {
+				return err
+			}
+		}
+		if !opts.exitZero {
+			os.Exit(1)
+		}
+		return nil
+	}
+
+	if opts.diff {
+		if !result.Changed {
+			return nil
+		}
+		return reporter.NewDiffReporter().Diff(path, string(content), result.Content)
+	}
+
+	_, err = io.WriteString(os.Stdout, result.Content)
+	return err
+}
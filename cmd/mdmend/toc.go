@@ -0,0 +1,109 @@
+package main
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/mohitmishra786/mdmend/internal/config"
+	"github.com/mohitmishra786/mdmend/internal/fixer"
+	"github.com/mohitmishra786/mdmend/internal/markdown"
+	"github.com/mohitmishra786/mdmend/internal/rules"
+	"github.com/spf13/cobra"
+)
+
+type tocOptions struct {
+	write    bool
+	minLevel int
+	maxLevel int
+	style    string
+	indent   int
+}
+
+func newTOCCmd() *cobra.Command {
+	var opts tocOptions
+
+	cmd := &cobra.Command{
+		Use:   "toc <files...>",
+		Short: "Print or update the table of contents of Markdown files",
+		Long: `Generate a table of contents from a file's headings.
+
+Without --write the list is printed. With --write the list between the
+file's <!-- toc --> and <!-- /toc --> markers (or doctoc's START and END
+markers) is replaced; a file without markers gets a TOC after its first
+heading.
+
+Flags default to the MD073 options in the config file, and the list style
+and indent follow MD004 and MD007 unless MD073 sets its own.`,
+		Args: cobra.MinimumNArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runTOC(cmd, args, opts)
+		},
+	}
+
+	cmd.Flags().BoolVarP(&opts.write, "write", "w", false, "Update the files instead of printing the TOC")
+	cmd.Flags().IntVar(&opts.minLevel, "min-level", 2, "Shallowest heading level to list")
+	cmd.Flags().IntVar(&opts.maxLevel, "max-level", 4, "Deepest heading level to list")
+	cmd.Flags().StringVar(&opts.style, "style", "dash", "List style: dash|asterisk|plus|ordered")
+	cmd.Flags().IntVar(&opts.indent, "indent", 2, "Spaces per nesting level of a bulleted TOC")
+	return cmd
+}
+
+func runTOC(cmd *cobra.Command, args []string, flags tocOptions) error {
+	switch flags.style {
+	case "dash", "asterisk", "plus", "ordered":
+	default:
+		return fmt.Errorf("invalid style %q: use dash, asterisk, plus, or ordered", flags.style)
+	}
+
+	cfg, err := loadConfig(globalOpts)
+	if err != nil {
+		return err
+	}
+
+	changed := cmd.Flags().Changed
+	for i, path := range args {
+		data, err := os.ReadFile(path)
+		if err != nil {
+			return err
+		}
+		rule := rules.ConfigureFromConfig(rules.Get("MD073"), config.ApplyFlavor(cfg, path)).(*rules.MD073)
+		if changed("min-level") {
+			rule.MinLevel = flags.minLevel
+		}
+		if changed("max-level") {
+			rule.MaxLevel = flags.maxLevel
+		}
+		if changed("style") {
+			rule.Style = flags.style
+		}
+		if changed("indent") {
+			rule.Indent = flags.indent
+		}
+		content, enc := markdown.Decode(string(data))
+
+		if !flags.write {
+			if len(args) > 1 {
+				if i > 0 {
+					fmt.Println()
+				}
+				fmt.Printf("%s:\n", displayPath(path))
+			}
+			for _, line := range rule.Generate(content) {
+				fmt.Println(line)
+			}
+			continue
+		}
+
+		result := rule.Update(content, true)
+		if !result.Changed {
+			continue
+		}
+		if err := fixer.AtomicWrite(path, []byte(enc.Encode(result.Content()))); err != nil {
+			return fmt.Errorf("writing %s: %w", path, err)
+		}
+		if !globalOpts.quiet {
+			fmt.Printf("Updated table of contents in %s\n", displayPath(path))
+		}
+	}
+	return nil
+}
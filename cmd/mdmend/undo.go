@@ -0,0 +1,106 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"os"
+
+	"github.com/mohitmishra786/mdmend/internal/fixer"
+	"github.com/mohitmishra786/mdmend/internal/journal"
+	"github.com/spf13/cobra"
+)
+
+func newUndoCmd() *cobra.Command {
+	var force bool
+
+	cmd := &cobra.Command{
+		Use:   "undo",
+		Short: "Restore the files changed by the last 'fix --backup' run",
+		Long: `Restore the files changed by the last 'mdmend fix --backup' run.
+
+Files edited since that run are skipped so newer work is not lost; use
+--force to restore them anyway. The journal is removed once every file has
+been restored.`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runUndo(globalOpts, force)
+		},
+	}
+
+	cmd.Flags().BoolVar(&force, "force", false, "Restore files even if they changed after the fix run")
+	return cmd
+}
+
+func runUndo(opts globalOptions, force bool) error {
+	j, err := journal.Load("")
+	if errors.Is(err, journal.ErrEmpty) {
+		fmt.Println("Nothing to undo.")
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+
+	restored, conflicts, err := j.Restore(force)
+	if !opts.quiet {
+		for _, path := range restored {
+			fmt.Printf("  restored %s\n", displayPath(path))
+		}
+	}
+	for _, c := range conflicts {
+		fmt.Fprintf(os.Stderr, "  skipped %s: %v\n", displayPath(c.Path), c.Err)
+	}
+	if err != nil {
+		return err
+	}
+
+	fmt.Printf("%d file(s) restored from the fix run at %s\n", len(restored), j.Created().Format("2006-01-02 15:04:05"))
+	if len(conflicts) > 0 {
+		return fmt.Errorf("%d file(s) changed since the fix run; rerun with --force to overwrite them", len(conflicts))
+	}
+	return nil
+}
+
+// startBackup begins a new journal when --backup is set and files will be
+// written.
+func (opts *fixOptions) startBackup() error {
+	if !opts.backup || opts.dryRun || opts.diff {
+		return nil
+	}
+	path, err := journal.DefaultPath()
+	if err != nil {
+		return err
+	}
+	opts.journal = journal.New(path)
+	return nil
+}
+
+// saveBackup stores the journal. A run that changed nothing leaves the
+// previous journal in place so 'mdmend undo' still reverts the last real fix.
+func (opts *fixOptions) saveBackup() error {
+	if opts.journal == nil || opts.journal.Len() == 0 {
+		return nil
+	}
+	if err := opts.journal.Save(); err != nil {
+		return fmt.Errorf("saving backup journal: %w", err)
+	}
+	if !opts.quiet && opts.output != "json" {
+		fmt.Printf("Backed up %d file(s); run 'mdmend undo' to restore them.\n", opts.journal.Len())
+	}
+	return nil
+}
+
+// write replaces path with fixed unless it no longer holds original, and
+// records the change in the backup journal.
+func (opts *fixOptions) write(path string, original []byte, fixed string) error {
+	err := fixer.NewPatcher(path).
+		AllowInvalidUTF8(opts.allowInvalidUTF8).
+		Expect(original).
+		Apply(fixed)
+	if err != nil {
+		return err
+	}
+	if opts.journal != nil {
+		return opts.journal.Record(path, original, []byte(fixed))
+	}
+	return nil
+}
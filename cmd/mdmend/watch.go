@@ -4,7 +4,6 @@ import (
 	"fmt"
 	"os"
 	"path/filepath"
-	"strings"
 	"time"
 
 	"github.com/fsnotify/fsnotify"
@@ -12,7 +11,6 @@ import (
 	"github.com/mohitmishra786/mdmend/internal/config"
 	"github.com/mohitmishra786/mdmend/internal/linter"
 	"github.com/mohitmishra786/mdmend/internal/reporter"
-	"github.com/mohitmishra786/mdmend/internal/walker"
 )
 
 func runLintWatch(args []string, opts *lintOptions) error {
@@ -25,8 +23,7 @@ func runLintWatch(args []string, opts *lintOptions) error {
 		return err
 	}
 
-	ignore := append(cfg.Ignore, opts.ignore...)
-	w := walker.New(ignore)
+	w := newWalker(cfg, opts.globalOptions)
 
 	files, err := w.Walk(args)
 	if err != nil {
@@ -108,9 +105,7 @@ func runLintWatch(args []string, opts *lintOptions) error {
 			if event.Op&(fsnotify.Write|fsnotify.Create) == 0 {
 				continue
 			}
-			if !strings.HasSuffix(strings.ToLower(event.Name), ".md") &&
-				!strings.HasSuffix(strings.ToLower(event.Name), ".mdx") &&
-				!strings.HasSuffix(strings.ToLower(event.Name), ".markdown") {
+			if !w.Includes(event.Name) {
 				continue
 			}
 			time.Sleep(100 * time.Millisecond)
@@ -0,0 +1,180 @@
+package baseline
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/mohitmishra786/mdmend/internal/rules"
+)
+
+const (
+	DefaultPath = ".mdmend-baseline.json"
+	version     = 1
+)
+
+// Entry records how many times a rule fired on lines with the same
+// fingerprint in one file.
+type Entry struct {
+	File        string `json:"file"`
+	Rule        string `json:"rule"`
+	Fingerprint string `json:"fingerprint"`
+	Count       int    `json:"count"`
+}
+
+type fileFormat struct {
+	Version int     `json:"version"`
+	Entries []Entry `json:"entries"`
+}
+
+type key struct {
+	file        string
+	rule        string
+	fingerprint string
+}
+
+// Baseline is a set of known violations. Violations are matched by file,
+// rule and a fingerprint of the offending line's text rather than its
+// number, so entries survive lines being inserted or removed above them.
+type Baseline struct {
+	entries map[key]int
+	checked map[string]bool
+}
+
+func New() *Baseline {
+	return &Baseline{
+		entries: make(map[key]int),
+		checked: make(map[string]bool),
+	}
+}
+
+func Load(path string) (*Baseline, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	var f fileFormat
+	if err := json.Unmarshal(data, &f); err != nil {
+		return nil, err
+	}
+
+	b := New()
+	for _, e := range f.Entries {
+		b.entries[key{e.File, e.Rule, e.Fingerprint}] += e.Count
+	}
+	return b, nil
+}
+
+func (b *Baseline) Save(path string) error {
+	data, err := json.MarshalIndent(fileFormat{Version: version, Entries: b.Entries()}, "", "  ")
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(path, append(data, '\n'), 0o644)
+}
+
+// Entries returns the recorded entries sorted by file, rule and fingerprint.
+func (b *Baseline) Entries() []Entry {
+	entries := make([]Entry, 0, len(b.entries))
+	for k, n := range b.entries {
+		if n > 0 {
+			entries = append(entries, Entry{File: k.file, Rule: k.rule, Fingerprint: k.fingerprint, Count: n})
+		}
+	}
+	sortEntries(entries)
+	return entries
+}
+
+// Len returns the number of violations recorded.
+func (b *Baseline) Len() int {
+	n := 0
+	for _, count := range b.entries {
+		n += count
+	}
+	return n
+}
+
+// Add records violations found in path.
+func (b *Baseline) Add(path, content string, violations []rules.Violation) {
+	file := normalizePath(path)
+	lines := strings.Split(content, "\n")
+	for _, v := range violations {
+		b.entries[key{file, v.Rule, Fingerprint(v.Rule, lineAt(lines, v.Line))}]++
+	}
+}
+
+// Filter removes violations in path that are covered by the baseline. Each
+// entry suppresses at most Count violations; the rest are returned as new.
+func (b *Baseline) Filter(path, content string, violations []rules.Violation) []rules.Violation {
+	file := normalizePath(path)
+	b.checked[file] = true
+
+	lines := strings.Split(content, "\n")
+	var remaining []rules.Violation
+	for _, v := range violations {
+		k := key{file, v.Rule, Fingerprint(v.Rule, lineAt(lines, v.Line))}
+		if b.entries[k] > 0 {
+			b.entries[k]--
+			continue
+		}
+		remaining = append(remaining, v)
+	}
+	return remaining
+}
+
+// Fixed returns the entries of files passed to Filter that no longer match
+// any violation, so the baseline can be tightened.
+func (b *Baseline) Fixed() []Entry {
+	var fixed []Entry
+	for k, n := range b.entries {
+		if n > 0 && b.checked[k.file] {
+			fixed = append(fixed, Entry{File: k.file, Rule: k.rule, Fingerprint: k.fingerprint, Count: n})
+		}
+	}
+	sortEntries(fixed)
+	return fixed
+}
+
+// Fingerprint identifies a violation by its rule and the trimmed text of the
+// line it was reported on.
+func Fingerprint(rule, line string) string {
+	sum := sha256.Sum256([]byte(rule + "\x00" + strings.TrimSpace(line)))
+	return hex.EncodeToString(sum[:8])
+}
+
+func lineAt(lines []string, n int) string {
+	if n < 1 || n > len(lines) {
+		return ""
+	}
+	return strings.TrimSuffix(lines[n-1], "\r")
+}
+
+// normalizePath makes paths relative to the working directory with forward
+// slashes, so a baseline written on one machine applies on another.
+func normalizePath(path string) string {
+	if abs, err := filepath.Abs(path); err == nil {
+		if cwd, err := os.Getwd(); err == nil {
+			if rel, err := filepath.Rel(cwd, abs); err == nil && !strings.HasPrefix(rel, "..") {
+				path = rel
+			}
+		}
+	}
+	return filepath.ToSlash(filepath.Clean(path))
+}
+
+func sortEntries(entries []Entry) {
+	sort.Slice(entries, func(i, j int) bool {
+		if entries[i].File != entries[j].File {
+			return entries[i].File < entries[j].File
+		}
+		if entries[i].Rule != entries[j].Rule {
+			return entries[i].Rule < entries[j].Rule
+		}
+		return entries[i].Fingerprint < entries[j].Fingerprint
+	})
+}
@@ -0,0 +1,69 @@
+package baseline
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/mohitmishra786/mdmend/internal/rules"
+)
+
+func TestBaselineFilterSurvivesLineShifts(t *testing.T) {
+	original := "# Title\n\nold  \nold  \n"
+	b := New()
+	b.Add("docs/a.md", original, []rules.Violation{
+		{Rule: "MD009", Line: 3},
+		{Rule: "MD009", Line: 4},
+	})
+
+	shifted := "# Title\n\nnew  \n\nold  \nold  \n"
+	got := b.Filter("docs/a.md", shifted, []rules.Violation{
+		{Rule: "MD009", Line: 3},
+		{Rule: "MD009", Line: 5},
+		{Rule: "MD009", Line: 6},
+	})
+	if len(got) != 1 || got[0].Line != 3 {
+		t.Errorf("Filter() = %v, want only the new violation on line 3", got)
+	}
+	if fixed := b.Fixed(); len(fixed) != 0 {
+		t.Errorf("Fixed() = %v, want none", fixed)
+	}
+}
+
+func TestBaselineCountsAndFixed(t *testing.T) {
+	content := "a  \na  \n"
+	b := New()
+	b.Add("a.md", content, []rules.Violation{{Rule: "MD009", Line: 1}, {Rule: "MD009", Line: 2}})
+	b.Add("b.md", content, []rules.Violation{{Rule: "MD009", Line: 1}})
+
+	got := b.Filter("a.md", "a  \n", []rules.Violation{{Rule: "MD009", Line: 1}})
+	if len(got) != 0 {
+		t.Errorf("Filter() = %v, want none", got)
+	}
+
+	fixed := b.Fixed()
+	if len(fixed) != 1 || fixed[0].File != "a.md" || fixed[0].Count != 1 {
+		t.Errorf("Fixed() = %v, want one a.md entry; b.md was not checked", fixed)
+	}
+}
+
+func TestBaselineSaveLoad(t *testing.T) {
+	b := New()
+	b.Add("./docs/../a.md", "x  \n", []rules.Violation{{Rule: "MD009", Line: 1}, {Rule: "MD047", Line: 9}})
+
+	path := filepath.Join(t.TempDir(), DefaultPath)
+	if err := b.Save(path); err != nil {
+		t.Fatal(err)
+	}
+	loaded, err := Load(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	entries := loaded.Entries()
+	if len(entries) != 2 || entries[0].File != "a.md" || entries[0].Rule != "MD009" {
+		t.Errorf("Entries() = %v", entries)
+	}
+	if loaded.Len() != 2 {
+		t.Errorf("Len() = %d, want 2", loaded.Len())
+	}
+}
@@ -54,6 +54,10 @@ func loadJSON(data []byte, cfg *Config) (*Config, error) {
 	return parsed, nil
 }
 
+// LoadIgnorePatterns returns the raw lines of the ignore files in path.
+//
+// Deprecated: the walker reads .gitignore, .mdmendignore and
+// .git/info/exclude itself, with full gitignore semantics.
 func LoadIgnorePatterns(path string) ([]string, error) {
 	patterns := []string{}
 
@@ -1,6 +1,7 @@
 package config
 
 import (
+	"errors"
 	"os"
 	"path/filepath"
 	"testing"
@@ -304,6 +305,35 @@ func TestPerFileFlavor(t *testing.T) {
 	}
 }
 
+func TestExtensionFlavor(t *testing.T) {
+	cfg := Default()
+
+	if flavor := ResolveFlavor(cfg, "components/Button.MDX"); flavor != FlavorMDX {
+		t.Errorf("ResolveFlavor(.MDX) = %q, want %q", flavor, FlavorMDX)
+	}
+	if flavor := ResolveFlavor(cfg, "README.md"); flavor != FlavorStandard {
+		t.Errorf("ResolveFlavor(.md) = %q, want %q", flavor, FlavorStandard)
+	}
+
+	cfg.Flavor = FlavorStandard
+	if flavor := ResolveFlavor(cfg, "page.mdx"); flavor != FlavorStandard {
+		t.Errorf("explicit flavor should win over extension, got %q", flavor)
+	}
+}
+
+func TestGetExtensions(t *testing.T) {
+	cfg := Default()
+	if got := cfg.GetExtensions(); len(got) != len(DefaultExtensions) {
+		t.Errorf("GetExtensions() = %v, want defaults %v", got, DefaultExtensions)
+	}
+
+	cfg.Extensions = []string{"MD", ".Mdx", " "}
+	got := cfg.GetExtensions()
+	if len(got) != 2 || got[0] != ".md" || got[1] != ".mdx" {
+		t.Errorf("GetExtensions() = %v, want [.md .mdx]", got)
+	}
+}
+
 func TestLoadIgnorePatterns(t *testing.T) {
 	tmpDir := t.TempDir()
 
@@ -380,3 +410,364 @@ func TestLoadYAMLError(t *testing.T) {
 		t.Error("Load() should return error for invalid YAML")
 	}
 }
+
+func TestToMarkdownlint(t *testing.T) {
+	cfg := Default()
+	cfg.Rules["MD049"] = RuleConfig{Style: "_"}
+	cfg.Rules["MD025"] = RuleConfig{Level: 1, FrontMatter: boolPtr(false)}
+
+	out := ToMarkdownlint(cfg, []string{"MD003", "MD013", "MD025", "MD049"})
+
+	if out["default"] != true {
+		t.Errorf("default = %v, want true", out["default"])
+	}
+	if out["MD013"] != false {
+		t.Errorf("MD013 = %v, want false", out["MD013"])
+	}
+	if out["MD060"] != false {
+		t.Errorf("unimplemented MD060 = %v, want false", out["MD060"])
+	}
+	if _, ok := out["MD073"]; ok {
+		t.Error("mdmend-only MD073 should not be exported")
+	}
+
+	md049, ok := out["MD049"].(map[string]interface{})
+	if !ok || md049["style"] != "underscore" {
+		t.Errorf("MD049 = %v, want style underscore", out["MD049"])
+	}
+	md025, ok := out["MD025"].(map[string]interface{})
+	if !ok || md025["front_matter_title"] != "" {
+		t.Errorf("MD025 = %v, want empty front_matter_title", out["MD025"])
+	}
+}
+
+func TestToMarkdownlintOnly(t *testing.T) {
+	cfg := Default()
+	cfg.Only = []string{"MD009", "MD003"}
+
+	out := ToMarkdownlint(cfg, nil)
+
+	if out["default"] != false {
+		t.Errorf("default = %v, want false", out["default"])
+	}
+	if out["MD009"] != true {
+		t.Errorf("MD009 = %v, want true", out["MD009"])
+	}
+	if _, ok := out["MD003"].(map[string]interface{}); !ok {
+		t.Errorf("MD003 = %v, want options object", out["MD003"])
+	}
+	if _, ok := out["MD010"]; ok {
+		t.Error("MD010 should be omitted when default is false")
+	}
+}
+
+func TestMarkdownlintRoundTrip(t *testing.T) {
+	cfg := Default()
+	cfg.Disable = append(cfg.Disable, "MD041")
+	cfg.Rules["MD007"] = RuleConfig{Indent: 4}
+	cfg.Rules["MD050"] = RuleConfig{Style: "__"}
+	cfg.Rules["MD033"] = RuleConfig{AllowedTags: []string{"br"}}
+	cfg.Rules["MD059"] = RuleConfig{ProhibitedTexts: []string{"hier", "mehr"}}
+
+	for _, tc := range []struct {
+		name   string
+		export func(*Config, []string) ([]byte, error)
+		parse  func([]byte) (*Config, error)
+	}{
+		{"json", ToMarkdownlintJSON, ParseMarkdownlintJSON},
+		{"yaml", ToMarkdownlintYAML, ParseMarkdownlintYAML},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			data, err := tc.export(cfg, nil)
+			if err != nil {
+				t.Fatalf("export error = %v", err)
+			}
+			parsed, err := tc.parse(data)
+			if err != nil {
+				t.Fatalf("parse error = %v\n%s", err, data)
+			}
+			if !parsed.IsDisabled("MD041") {
+				t.Error("MD041 should stay disabled")
+			}
+			if rc := parsed.GetRuleConfig("MD007"); rc.Indent != 4 {
+				t.Errorf("MD007 indent = %d, want 4", rc.Indent)
+			}
+			if rc := parsed.GetRuleConfig("MD050"); rc.Style != "__" {
+				t.Errorf("MD050 style = %q, want __", rc.Style)
+			}
+			if !parsed.IsDisabled("MD033") {
+				t.Error("MD033 should stay disabled")
+			}
+			if rc := parsed.GetRuleConfig("MD059"); len(rc.ProhibitedTexts) != 2 || rc.ProhibitedTexts[1] != "mehr" {
+				t.Errorf("MD059 prohibited_texts = %v", rc.ProhibitedTexts)
+			}
+		})
+	}
+}
+
+func TestMarkdownlintExportWarnings(t *testing.T) {
+	if warnings := MarkdownlintExportWarnings(&Config{}); len(warnings) != 0 {
+		t.Errorf("empty config warnings = %v, want none", warnings)
+	}
+
+	cfg := Default()
+	cfg.Flavor = FlavorMDX
+	cfg.Aggressive = true
+	if warnings := MarkdownlintExportWarnings(cfg); len(warnings) != 3 {
+		t.Errorf("got %d warnings, want 3: %v", len(warnings), warnings)
+	}
+}
+
+func TestApplyOverride(t *testing.T) {
+	cfg := Default()
+	exprs := []string{
+		"MD013.line_length=100",
+		"md013.code_blocks=false",
+		"MD040.confidence=0.8",
+		"MD048.style=tilde",
+		"MD044.names=JavaScript, GitHub",
+	}
+	if err := ApplyOverrides(cfg, exprs); err != nil {
+		t.Fatalf("ApplyOverrides: %v", err)
+	}
+
+	if got := cfg.Rules["MD013"].LineLength; got != 100 {
+		t.Errorf("MD013 line_length = %d, want 100", got)
+	}
+	if cb := cfg.Rules["MD013"].CodeBlocks; cb == nil || *cb {
+		t.Errorf("MD013 code_blocks = %v, want false", cb)
+	}
+	if got := cfg.Rules["MD040"].Confidence; got != 0.8 {
+		t.Errorf("MD040 confidence = %v, want 0.8", got)
+	}
+	if got := cfg.Rules["MD040"].Fallback; got != "text" {
+		t.Errorf("MD040 fallback = %q, want existing value kept", got)
+	}
+	if got := cfg.Rules["MD048"].Style; got != "tilde" {
+		t.Errorf("MD048 style = %q, want tilde", got)
+	}
+	if got := cfg.Rules["MD044"].Names; len(got) != 2 || got[1] != "GitHub" {
+		t.Errorf("MD044 names = %v", got)
+	}
+}
+
+func TestApplyOverrideErrors(t *testing.T) {
+	tests := []string{
+		"MD013",
+		"MD013=100",
+		"MD13.line_length=100",
+		"MD013.width=100",
+		"MD013.line_length=wide",
+		"MD013.code_blocks=maybe",
+		"MD040.confidence=high",
+	}
+	for _, expr := range tests {
+		t.Run(expr, func(t *testing.T) {
+			if err := ApplyOverride(Default(), expr); err == nil {
+				t.Errorf("ApplyOverride(%q) succeeded, want error", expr)
+			}
+		})
+	}
+}
+
+func TestApplyEnvOverrides(t *testing.T) {
+	cfg := Default()
+	environ := []string{
+		"PATH=/usr/bin",
+		"MDMEND_CONFIG=ignored",
+		"MDMEND_MD013_LINE_LENGTH=120",
+		"MDMEND_MD034_SKIP_PATTERNS=localhost,example.com",
+	}
+	if err := ApplyEnvOverrides(cfg, environ); err != nil {
+		t.Fatalf("ApplyEnvOverrides: %v", err)
+	}
+	if got := cfg.Rules["MD013"].LineLength; got != 120 {
+		t.Errorf("MD013 line_length = %d, want 120", got)
+	}
+	if got := cfg.Rules["MD034"].SkipPatterns; len(got) != 2 {
+		t.Errorf("MD034 skip_patterns = %v, want 2 entries", got)
+	}
+
+	err := ApplyEnvOverrides(Default(), []string{"MDMEND_MD013_LINE_LENGTH=abc"})
+	var oe *OverrideError
+	if !errors.As(err, &oe) || oe.Source != "MDMEND_MD013_LINE_LENGTH" {
+		t.Errorf("error = %v, want OverrideError naming the variable", err)
+	}
+}
+
+func TestIsEnabledSelectors(t *testing.T) {
+	SetRuleLabels(func(ruleID string) []string {
+		switch ruleID {
+		case "MD034":
+			return []string{"no-bare-urls", "links"}
+		case "MD057":
+			return []string{"broken-links", "links"}
+		}
+		return nil
+	})
+	defer SetRuleLabels(nil)
+
+	only := &Config{Only: []string{"LINKS", "~MD057"}}
+	disable := &Config{Disable: []string{"no-bare-urls"}}
+	tests := []struct {
+		cfg    *Config
+		ruleID string
+		want   bool
+	}{
+		{only, "MD034", true},
+		{only, "MD057", false},
+		{only, "MD009", false},
+		{&Config{Only: []string{"~links"}}, "MD009", true},
+		{&Config{Only: []string{"~links"}}, "MD034", false},
+		{disable, "MD034", false},
+		{disable, "MD057", true},
+	}
+	for _, tt := range tests {
+		if got := tt.cfg.IsEnabled(tt.ruleID); got != tt.want {
+			t.Errorf("IsEnabled(%s) with only=%v disable=%v = %v, want %v", tt.ruleID, tt.cfg.Only, tt.cfg.Disable, got, tt.want)
+		}
+	}
+
+	exclude := Default()
+	exclude.Only = []string{"~MD057"}
+	for ruleID, want := range map[string]bool{"MD057": false, "MD013": false, "MD075": false, "MD009": true} {
+		if got := exclude.IsEnabled(ruleID); got != want {
+			t.Errorf("IsEnabled(%s) with only=[~MD057] = %v, want %v", ruleID, got, want)
+		}
+	}
+}
+
+func TestIsEnabledResolution(t *testing.T) {
+	tests := []struct {
+		name   string
+		mutate func(*Config)
+		ruleID string
+		want   bool
+	}{
+		{"default on", func(c *Config) {}, "MD009", true},
+		{"default off", func(c *Config) {}, "MD013", false},
+		{"rule enabled overrides default disable list", func(c *Config) {
+			c.Rules["MD070"] = RuleConfig{Enabled: boolPtr(true)}
+		}, "MD070", true},
+		{"enable list", func(c *Config) { c.Enable = []string{"MD013"} }, "MD013", true},
+		{"only selects default-off rule", func(c *Config) { c.Only = []string{"MD013"} }, "MD013", true},
+		{"select enables", func(c *Config) { c.Select = []string{"MD013"} }, "MD013", true},
+		{"select disables", func(c *Config) { c.Select = []string{"~MD009"} }, "MD009", false},
+		{"select later wins", func(c *Config) { c.Select = []string{"~MD0*", "+MD009"} }, "MD009", true},
+		{"select wildcard", func(c *Config) { c.Select = []string{"-md0*"} }, "MD047", false},
+		{"select reset", func(c *Config) {
+			c.Enable = []string{"MD013"}
+			c.Select = []string{"=MD013"}
+		}, "MD013", false},
+		{"select reset keeps default-on", func(c *Config) {
+			c.Disable = []string{"MD009"}
+			c.Select = []string{"=MD00*"}
+		}, "MD009", true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := Default()
+			tt.mutate(cfg)
+			if got := cfg.IsEnabled(tt.ruleID); got != tt.want {
+				t.Errorf("IsEnabled(%s) = %v, want %v", tt.ruleID, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestParseSelection(t *testing.T) {
+	tests := []struct {
+		expr string
+		op   SelectionOp
+		sel  string
+	}{
+		{"MD013", SelectEnable, "MD013"},
+		{"+links", SelectEnable, "links"},
+		{"~MD013", SelectDisable, "MD013"},
+		{"-MD0*", SelectDisable, "MD0*"},
+		{"=MD033", SelectReset, "MD033"},
+	}
+	for _, tt := range tests {
+		op, sel := ParseSelection(tt.expr)
+		if op != tt.op || sel != tt.sel {
+			t.Errorf("ParseSelection(%q) = %v, %q; want %v, %q", tt.expr, op, sel, tt.op, tt.sel)
+		}
+	}
+}
+
+func TestMarkdownlintEnablesDefaultOffRule(t *testing.T) {
+	cfg, err := ParseMarkdownlintJSON([]byte(`{"MD013": {"line_length": 100}, "MD033": true, "MD009": false}`))
+	if err != nil {
+		t.Fatalf("ParseMarkdownlintJSON: %v", err)
+	}
+	for id, want := range map[string]bool{"MD013": true, "MD033": true, "MD009": false} {
+		if got := cfg.IsEnabled(id); got != want {
+			t.Errorf("IsEnabled(%s) = %v, want %v", id, got, want)
+		}
+	}
+}
+
+func TestParseSize(t *testing.T) {
+	tests := []struct {
+		in      string
+		want    int64
+		wantErr bool
+	}{
+		{"", 0, false},
+		{"2048", 2048, false},
+		{"512KB", 512 << 10, false},
+		{"1.5mb", 3 << 19, false},
+		{"2G", 2 << 30, false},
+		{"10 MB", 10 << 20, false},
+		{"lots", 0, true},
+		{"-1KB", 0, true},
+	}
+	for _, tt := range tests {
+		got, err := ParseSize(tt.in)
+		if (err != nil) != tt.wantErr || got != tt.want {
+			t.Errorf("ParseSize(%q) = %d, %v; want %d, error %v", tt.in, got, err, tt.want, tt.wantErr)
+		}
+	}
+}
+
+func TestParseFixLevel(t *testing.T) {
+	tests := map[string]string{"": FixLevelUnsafe, "Safe": FixLevelSafe, "unsafe": FixLevelUnsafe}
+	for in, want := range tests {
+		if got, err := ParseFixLevel(in); err != nil || got != want {
+			t.Errorf("ParseFixLevel(%q) = %q, %v; want %q", in, got, err, want)
+		}
+	}
+	if _, err := ParseFixLevel("aggressive"); err == nil {
+		t.Error("ParseFixLevel(aggressive) should fail")
+	}
+
+	cfg := Default()
+	if err := ApplyOverride(cfg, "MD044.fix=false"); err != nil {
+		t.Fatal(err)
+	}
+	if cfg.FixEnabled("MD044") || !cfg.FixEnabled("MD009") {
+		t.Error("MD044.fix=false should only disable MD044 fixes")
+	}
+}
+
+func TestApplyFlavorSlugStyle(t *testing.T) {
+	cfg := Default()
+	cfg.PerFileFlavor = map[string]string{"docs/**": FlavorMkDocs}
+	for path, want := range map[string]string{
+		"README.md":     "",
+		"docs/index.md": "mkdocs",
+		"page.mdx":      "docusaurus",
+	} {
+		if got := ApplyFlavor(cfg, path).SlugStyle; got != want {
+			t.Errorf("ApplyFlavor(%q).SlugStyle = %q, want %q", path, got, want)
+		}
+	}
+
+	cfg.SlugStyle = "Hugo"
+	if got := ApplyFlavor(cfg, "docs/index.md").SlugStyle; got != "hugo" {
+		t.Errorf("explicit slug_style = %q, want hugo", got)
+	}
+	if _, err := ParseSlugStyle("kramdown"); err == nil {
+		t.Error("ParseSlugStyle(kramdown) should fail")
+	}
+}
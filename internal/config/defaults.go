@@ -1,14 +1,24 @@
 package config
 
 type Config struct {
-	Disable       []string              `yaml:"disable"`
-	Only          []string              `yaml:"only"`
-	Rules         map[string]RuleConfig `yaml:"rules"`
-	Ignore        []string              `yaml:"ignore"`
-	TabSize       int                   `yaml:"tab_size"`
-	Aggressive    bool                  `yaml:"aggressive"`
-	Flavor        string                `yaml:"flavor"`
-	PerFileFlavor map[string]string     `yaml:"per_file_flavor"`
+	Disable        []string              `yaml:"disable"`
+	Enable         []string              `yaml:"enable"`
+	Only           []string              `yaml:"only"`
+	Select         []string              `yaml:"select"`
+	Rules          map[string]RuleConfig `yaml:"rules"`
+	Ignore         []string              `yaml:"ignore"`
+	Extensions     []string              `yaml:"extensions"`
+	Include        []string              `yaml:"include"`
+	MaxFileSize    string                `yaml:"max_file_size"`
+	MaxDepth       int                   `yaml:"max_depth"`
+	FollowSymlinks bool                  `yaml:"follow_symlinks"`
+	EndOfLine      string                `yaml:"end_of_line"`
+	TabSize        int                   `yaml:"tab_size"`
+	Aggressive     bool                  `yaml:"aggressive"`
+	FixLevel       string                `yaml:"fix_level"`
+	Flavor         string                `yaml:"flavor"`
+	PerFileFlavor  map[string]string     `yaml:"per_file_flavor"`
+	SlugStyle      string                `yaml:"slug_style"`
 }
 
 type RuleConfig struct {
@@ -36,6 +46,23 @@ type RuleConfig struct {
 	Tables                *bool    `yaml:"tables"`
 	Level                 int      `yaml:"level"`
 	SuggestDemotion       *bool    `yaml:"suggest_demotion"`
+	Fix                   *bool    `yaml:"fix"`
+	Vocabulary            string   `yaml:"vocabulary"`
+	Dictionaries          []string `yaml:"dictionaries"`
+	Words                 []string `yaml:"words"`
+	ProhibitedTexts       []string `yaml:"prohibited_texts"`
+	Languages             []string `yaml:"languages"`
+	Reflow                string   `yaml:"reflow"`
+	SmallWords            []string `yaml:"small_words"`
+	Allow                 []string `yaml:"allow"`
+	Deny                  []string `yaml:"deny"`
+	Timeout               string   `yaml:"timeout"`
+	HostInterval          string   `yaml:"host_interval"`
+	CacheTTL              string   `yaml:"cache_ttl"`
+	Concurrency           int      `yaml:"concurrency"`
+	Retries               int      `yaml:"retries"`
+	MaxLevel              int      `yaml:"max_level"`
+	Insert                *bool    `yaml:"insert"`
 }
 
 func Default() *Config {
@@ -47,11 +74,10 @@ func Default() *Config {
 			"MD007": {Indent: 2},
 			"MD010": {TabSize: 4},
 			"MD013": {LineLength: 120, CodeBlocks: boolPtr(false), Tables: boolPtr(false), Enabled: boolPtr(false)},
-			"MD014": {Enabled: boolPtr(true), Smart: boolPtr(true)},
+			"MD014": {Smart: boolPtr(true)},
 			"MD024": {AllowDifferentNesting: boolPtr(true)},
 			"MD025": {Level: 1, FrontMatter: boolPtr(true), SuggestDemotion: boolPtr(false)},
 			"MD026": {Punctuation: ".,;:!"},
-			"MD028": {Enabled: boolPtr(true)},
 			"MD033": {Enabled: boolPtr(false), AllowedTags: []string{}},
 			"MD034": {Style: "angle", SkipPatterns: []string{}},
 			"MD036": {Suggest: boolPtr(false), Punctuation: ".,;:!?"},
@@ -66,7 +92,11 @@ func Default() *Config {
 			"MD054": {Style: "consistent"},
 			"MD070": {Enabled: boolPtr(false)},
 			"MD073": {Enabled: boolPtr(false)},
+			"MD075": {Enabled: boolPtr(false)},
+			"MD076": {Enabled: boolPtr(false), Style: "sentence"},
+			"MD077": {Enabled: boolPtr(false)},
 			"MD056": {PadShortRows: boolPtr(true)},
+			"MD060": {Style: "any"},
 			"MD057": {SuggestClosest: boolPtr(true)},
 		},
 		Ignore:     []string{"node_modules/", "vendor/", "*.generated.md", "CHANGELOG.md"},
@@ -83,23 +113,6 @@ func (c *Config) IsDisabled(ruleID string) bool {
 	return !c.IsEnabled(ruleID)
 }
 
-func (c *Config) IsEnabled(ruleID string) bool {
-	if len(c.Only) > 0 {
-		for _, id := range c.Only {
-			if id == ruleID {
-				return true
-			}
-		}
-		return false
-	}
-	for _, id := range c.Disable {
-		if id == ruleID {
-			return false
-		}
-	}
-	return true
-}
-
 func (c *Config) GetRuleConfig(ruleID string) RuleConfig {
 	if rc, ok := c.Rules[ruleID]; ok {
 		return rc
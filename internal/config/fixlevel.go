@@ -0,0 +1,42 @@
+package config
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Fix levels. Safe fixes only touch whitespace and markup structure; unsafe
+// fixes may change document content or rely on heuristics.
+const (
+	FixLevelSafe   = "safe"
+	FixLevelUnsafe = "unsafe"
+)
+
+// ParseFixLevel validates a fix_level setting. An empty level means unsafe.
+func ParseFixLevel(level string) (string, error) {
+	switch l := strings.ToLower(strings.TrimSpace(level)); l {
+	case "":
+		return FixLevelUnsafe, nil
+	case FixLevelSafe, FixLevelUnsafe:
+		return l, nil
+	default:
+		return "", fmt.Errorf("invalid fix_level %q: use safe or unsafe", level)
+	}
+}
+
+// GetFixLevel returns the configured fix level, defaulting to unsafe so
+// every fixable rule is applied.
+func (c *Config) GetFixLevel() string {
+	level, err := ParseFixLevel(c.FixLevel)
+	if err != nil {
+		return FixLevelUnsafe
+	}
+	return level
+}
+
+// FixEnabled reports whether fixes for ruleID are allowed by its per-rule
+// fix setting. Rules with fix: false are still linted.
+func (c *Config) FixEnabled(ruleID string) bool {
+	rc := c.GetRuleConfig(ruleID)
+	return rc.Fix == nil || *rc.Fix
+}
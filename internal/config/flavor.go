@@ -1,10 +1,12 @@
 package config
 
 import (
+	"fmt"
 	"path/filepath"
 	"strings"
 
 	"github.com/bmatcuk/doublestar/v4"
+	"github.com/mohitmishra786/mdmend/internal/slug"
 )
 
 const (
@@ -13,6 +15,47 @@ const (
 	FlavorMkDocs   = "mkdocs"
 )
 
+// DefaultExtensions are the file extensions treated as Markdown when the
+// config does not set extensions.
+var DefaultExtensions = []string{".md", ".markdown", ".mdown", ".mkd", ".mdx", ".qmd"}
+
+var extensionFlavors = map[string]string{
+	".mdx": FlavorMDX,
+}
+
+// flavorSlugStyles are the heading anchor schemes of the site generators
+// each flavor is usually published with. Other flavors use GitHub's.
+var flavorSlugStyles = map[string]string{
+	FlavorMDX:    slug.Docusaurus,
+	FlavorMkDocs: slug.MkDocs,
+}
+
+// GetExtensions returns the configured Markdown extensions, lower-cased and
+// dot-prefixed, falling back to DefaultExtensions.
+func (c *Config) GetExtensions() []string {
+	if c == nil || len(c.Extensions) == 0 {
+		return append([]string{}, DefaultExtensions...)
+	}
+	exts := make([]string, 0, len(c.Extensions))
+	for _, ext := range c.Extensions {
+		ext = strings.ToLower(strings.TrimSpace(ext))
+		if ext == "" {
+			continue
+		}
+		if !strings.HasPrefix(ext, ".") {
+			ext = "." + ext
+		}
+		exts = append(exts, ext)
+	}
+	return exts
+}
+
+// ExtensionFlavor returns the default flavor for path's extension, or "" if
+// the extension has none.
+func ExtensionFlavor(path string) string {
+	return extensionFlavors[strings.ToLower(filepath.Ext(path))]
+}
+
 func ValidFlavor(flavor string) bool {
 	switch strings.ToLower(strings.TrimSpace(flavor)) {
 	case "", FlavorStandard, FlavorMDX, FlavorMkDocs:
@@ -30,6 +73,16 @@ func NormalizeFlavor(flavor string) string {
 	return flavor
 }
 
+// ParseSlugStyle validates a slug_style setting. An empty style means the
+// flavor picks one.
+func ParseSlugStyle(style string) (string, error) {
+	s := strings.ToLower(strings.TrimSpace(style))
+	if !slug.Valid(s) {
+		return "", fmt.Errorf("invalid slug_style %q: use %s", style, strings.Join(slug.Styles, ", "))
+	}
+	return s, nil
+}
+
 func ResolveFlavor(cfg *Config, path string) string {
 	if cfg == nil {
 		return FlavorStandard
@@ -41,6 +94,12 @@ func ResolveFlavor(cfg *Config, path string) string {
 		}
 	}
 
+	if cfg.Flavor == "" {
+		if flavor := ExtensionFlavor(path); flavor != "" {
+			return flavor
+		}
+	}
+
 	return NormalizeFlavor(cfg.Flavor)
 }
 
@@ -73,6 +132,11 @@ func ApplyFlavor(cfg *Config, path string) *Config {
 	case FlavorMkDocs:
 		applyMkDocsFlavor(cloned)
 	}
+	if style, err := ParseSlugStyle(cloned.SlugStyle); err == nil && style != "" {
+		cloned.SlugStyle = style
+	} else {
+		cloned.SlugStyle = flavorSlugStyles[flavor]
+	}
 
 	return cloned
 }
@@ -82,12 +146,24 @@ func cloneConfig(cfg *Config) *Config {
 	if cfg.Disable != nil {
 		cloned.Disable = append([]string{}, cfg.Disable...)
 	}
+	if cfg.Enable != nil {
+		cloned.Enable = append([]string{}, cfg.Enable...)
+	}
 	if cfg.Only != nil {
 		cloned.Only = append([]string{}, cfg.Only...)
 	}
+	if cfg.Select != nil {
+		cloned.Select = append([]string{}, cfg.Select...)
+	}
 	if cfg.Ignore != nil {
 		cloned.Ignore = append([]string{}, cfg.Ignore...)
 	}
+	if cfg.Extensions != nil {
+		cloned.Extensions = append([]string{}, cfg.Extensions...)
+	}
+	if cfg.Include != nil {
+		cloned.Include = append([]string{}, cfg.Include...)
+	}
 	if cfg.PerFileFlavor != nil {
 		cloned.PerFileFlavor = make(map[string]string, len(cfg.PerFileFlavor))
 		for k, v := range cfg.PerFileFlavor {
@@ -3,6 +3,7 @@ package config
 import (
 	"encoding/json"
 	"fmt"
+	"sort"
 	"strings"
 
 	"gopkg.in/yaml.v3"
@@ -46,14 +47,38 @@ func ParseMarkdownlintJSON(data []byte) (*Config, error) {
 			continue
 		}
 		ruleID := strings.ToUpper(key)
-		if err := applyMarkdownlintRule(cfg, ruleID, value, defaultEnabled); err != nil {
+		if err := applyMarkdownlintRule(cfg, ruleID, value); err != nil {
 			return nil, fmt.Errorf("rule %s: %w", ruleID, err)
 		}
+		if !defaultEnabled && markdownlintRuleEnabled(value) {
+			cfg.Only = append(cfg.Only, ruleID)
+		}
 	}
+	sort.Strings(cfg.Only)
 
 	return cfg, nil
 }
 
+func ParseMarkdownlintYAML(data []byte) (*Config, error) {
+	var raw map[string]interface{}
+	if err := yaml.Unmarshal(data, &raw); err != nil {
+		return nil, err
+	}
+	converted, err := json.Marshal(raw)
+	if err != nil {
+		return nil, err
+	}
+	return ParseMarkdownlintJSON(converted)
+}
+
+func markdownlintRuleEnabled(raw json.RawMessage) bool {
+	var enabled bool
+	if err := json.Unmarshal(raw, &enabled); err == nil {
+		return enabled
+	}
+	return true
+}
+
 func applyDirectConfig(cfg *Config, direct markdownlintConfig) {
 	if len(direct.Disable) > 0 {
 		cfg.Disable = append(cfg.Disable, direct.Disable...)
@@ -68,20 +93,14 @@ func applyDirectConfig(cfg *Config, direct markdownlintConfig) {
 		cfg.Ignore = append(cfg.Ignore, direct.Ignores...)
 	}
 	for ruleID, raw := range direct.Rules {
-		_ = applyMarkdownlintRule(cfg, strings.ToUpper(ruleID), raw, true)
+		_ = applyMarkdownlintRule(cfg, strings.ToUpper(ruleID), raw)
 	}
 }
 
-func applyMarkdownlintRule(cfg *Config, ruleID string, raw json.RawMessage, defaultEnabled bool) error {
+func applyMarkdownlintRule(cfg *Config, ruleID string, raw json.RawMessage) error {
 	var enabled bool
 	if err := json.Unmarshal(raw, &enabled); err == nil {
-		if !enabled {
-			if !containsRule(cfg.Disable, ruleID) {
-				cfg.Disable = append(cfg.Disable, ruleID)
-			}
-		} else if !defaultEnabled {
-			cfg.Disable = removeRule(cfg.Disable, ruleID)
-		}
+		setMarkdownlintRuleEnabled(cfg, ruleID, enabled)
 		return nil
 	}
 
@@ -99,10 +118,24 @@ func applyMarkdownlintRule(cfg *Config, ruleID string, raw json.RawMessage, defa
 		return err
 	}
 	cfg.Rules[ruleID] = rc
-	cfg.Disable = removeRule(cfg.Disable, ruleID)
+	setMarkdownlintRuleEnabled(cfg, ruleID, true)
 	return nil
 }
 
+func setMarkdownlintRuleEnabled(cfg *Config, ruleID string, enabled bool) {
+	if !enabled {
+		cfg.Enable = removeRule(cfg.Enable, ruleID)
+		if !containsRule(cfg.Disable, ruleID) {
+			cfg.Disable = append(cfg.Disable, ruleID)
+		}
+		return
+	}
+	cfg.Disable = removeRule(cfg.Disable, ruleID)
+	if !cfg.IsEnabled(ruleID) && !containsRule(cfg.Enable, ruleID) {
+		cfg.Enable = append(cfg.Enable, ruleID)
+	}
+}
+
 func mapMarkdownlintOptions(ruleID string, options map[string]json.RawMessage, rc *RuleConfig) error {
 	for key, raw := range options {
 		switch key {
@@ -111,7 +144,7 @@ func mapMarkdownlintOptions(ruleID string, options map[string]json.RawMessage, r
 			if err := json.Unmarshal(raw, &style); err != nil {
 				return err
 			}
-			rc.Style = style
+			rc.Style = fromMarkdownlintStyle(ruleID, style)
 		case "tab_size", "spaces_per_tab":
 			var size int
 			if err := json.Unmarshal(raw, &size); err != nil {
@@ -148,7 +181,13 @@ func mapMarkdownlintOptions(ruleID string, options map[string]json.RawMessage, r
 				return err
 			}
 			rc.Headings = headings
-		case "allowed_tags":
+		case "prohibited_texts":
+			var texts []string
+			if err := json.Unmarshal(raw, &texts); err != nil {
+				return err
+			}
+			rc.ProhibitedTexts = texts
+		case "allowed_tags", "allowed_elements":
 			var tags []string
 			if err := json.Unmarshal(raw, &tags); err != nil {
 				return err
@@ -178,7 +217,19 @@ func mapMarkdownlintOptions(ruleID string, options map[string]json.RawMessage, r
 				return err
 			}
 			rc.Level = level
-		case "code_blocks", "tables", "enabled", "smart", "front_matter", "front_matter_title",
+		case "front_matter_title":
+			var title string
+			if err := json.Unmarshal(raw, &title); err == nil {
+				value := title != ""
+				rc.FrontMatter = &value
+				continue
+			}
+			var value bool
+			if err := json.Unmarshal(raw, &value); err != nil {
+				return err
+			}
+			rc.FrontMatter = &value
+		case "code_blocks", "tables", "enabled", "smart", "front_matter",
 			"siblings_only", "allow_different_nesting", "suggest", "suggest_closest",
 			"pad_short_rows", "derive_from_filename", "promote_first", "suggest_demotion":
 			var value bool
@@ -194,7 +245,7 @@ func mapMarkdownlintOptions(ruleID string, options map[string]json.RawMessage, r
 				rc.Enabled = &value
 			case "smart":
 				rc.Smart = &value
-			case "front_matter", "front_matter_title":
+			case "front_matter":
 				rc.FrontMatter = &value
 			case "siblings_only", "allow_different_nesting":
 				rc.AllowDifferentNesting = &value
@@ -236,14 +287,24 @@ func removeRule(items []string, target string) []string {
 
 func ToYAML(cfg *Config) ([]byte, error) {
 	type yamlConfig struct {
-		Disable       []string                  `yaml:"disable,omitempty"`
-		Only          []string                  `yaml:"only,omitempty"`
-		Rules         map[string]yamlRuleConfig `yaml:"rules,omitempty"`
-		Ignore        []string                  `yaml:"ignore,omitempty"`
-		TabSize       int                       `yaml:"tab_size,omitempty"`
-		Aggressive    bool                      `yaml:"aggressive,omitempty"`
-		Flavor        string                    `yaml:"flavor,omitempty"`
-		PerFileFlavor map[string]string         `yaml:"per_file_flavor,omitempty"`
+		Disable        []string                  `yaml:"disable,omitempty"`
+		Enable         []string                  `yaml:"enable,omitempty"`
+		Only           []string                  `yaml:"only,omitempty"`
+		Select         []string                  `yaml:"select,omitempty"`
+		Rules          map[string]yamlRuleConfig `yaml:"rules,omitempty"`
+		Ignore         []string                  `yaml:"ignore,omitempty"`
+		Extensions     []string                  `yaml:"extensions,omitempty"`
+		Include        []string                  `yaml:"include,omitempty"`
+		MaxFileSize    string                    `yaml:"max_file_size,omitempty"`
+		MaxDepth       int                       `yaml:"max_depth,omitempty"`
+		FollowSymlinks bool                      `yaml:"follow_symlinks,omitempty"`
+		EndOfLine      string                    `yaml:"end_of_line,omitempty"`
+		TabSize        int                       `yaml:"tab_size,omitempty"`
+		Aggressive     bool                      `yaml:"aggressive,omitempty"`
+		FixLevel       string                    `yaml:"fix_level,omitempty"`
+		Flavor         string                    `yaml:"flavor,omitempty"`
+		PerFileFlavor  map[string]string         `yaml:"per_file_flavor,omitempty"`
+		SlugStyle      string                    `yaml:"slug_style,omitempty"`
 	}
 
 	rules := make(map[string]yamlRuleConfig)
@@ -256,12 +317,22 @@ func ToYAML(cfg *Config) ([]byte, error) {
 	}
 
 	out := yamlConfig{
-		Disable:       dedupeStrings(cfg.Disable),
-		Only:          cfg.Only,
-		Rules:         rules,
-		Ignore:        cfg.Ignore,
-		Flavor:        cfg.Flavor,
-		PerFileFlavor: cfg.PerFileFlavor,
+		Disable:        dedupeStrings(cfg.Disable),
+		Enable:         dedupeStrings(cfg.Enable),
+		Only:           cfg.Only,
+		Select:         cfg.Select,
+		Rules:          rules,
+		Ignore:         cfg.Ignore,
+		Extensions:     cfg.Extensions,
+		Include:        cfg.Include,
+		MaxFileSize:    cfg.MaxFileSize,
+		MaxDepth:       cfg.MaxDepth,
+		FollowSymlinks: cfg.FollowSymlinks,
+		EndOfLine:      cfg.EndOfLine,
+		FixLevel:       cfg.FixLevel,
+		Flavor:         cfg.Flavor,
+		PerFileFlavor:  cfg.PerFileFlavor,
+		SlugStyle:      cfg.SlugStyle,
 	}
 	if cfg.TabSize != 0 && cfg.TabSize != 4 {
 		out.TabSize = cfg.TabSize
@@ -303,7 +374,24 @@ func ruleConfigIsEmpty(rc yamlRuleConfig) bool {
 		rc.CodeBlocks == nil &&
 		rc.Tables == nil &&
 		rc.Level == 0 &&
-		rc.SuggestDemotion == nil
+		rc.SuggestDemotion == nil &&
+		rc.Vocabulary == "" &&
+		len(rc.Dictionaries) == 0 &&
+		len(rc.Words) == 0 &&
+		len(rc.ProhibitedTexts) == 0 &&
+		len(rc.Languages) == 0 &&
+		rc.Reflow == "" &&
+		len(rc.SmallWords) == 0 &&
+		len(rc.Allow) == 0 &&
+		len(rc.Deny) == 0 &&
+		rc.Timeout == "" &&
+		rc.HostInterval == "" &&
+		rc.CacheTTL == "" &&
+		rc.Concurrency == 0 &&
+		rc.Retries == 0 &&
+		rc.MaxLevel == 0 &&
+		rc.Insert == nil &&
+		rc.Fix == nil
 }
 
 func dedupeStrings(items []string) []string {
@@ -318,3 +406,233 @@ func dedupeStrings(items []string) []string {
 	}
 	return out
 }
+
+var markdownlintRuleIDs = []string{
+	"MD001", "MD003", "MD004", "MD005", "MD007", "MD009", "MD010", "MD011", "MD012",
+	"MD013", "MD014", "MD018", "MD019", "MD020", "MD021", "MD022", "MD023", "MD024",
+	"MD025", "MD026", "MD027", "MD028", "MD029", "MD030", "MD031", "MD032", "MD033",
+	"MD034", "MD035", "MD036", "MD037", "MD038", "MD039", "MD040", "MD041", "MD042",
+	"MD043", "MD044", "MD045", "MD046", "MD047", "MD048", "MD049", "MD050", "MD051",
+	"MD052", "MD053", "MD054", "MD055", "MD056", "MD058", "MD059", "MD060",
+}
+
+func MarkdownlintRuleIDs() []string {
+	return append([]string(nil), markdownlintRuleIDs...)
+}
+
+func IsMarkdownlintRule(ruleID string) bool {
+	return containsRule(markdownlintRuleIDs, ruleID)
+}
+
+func fromMarkdownlintStyle(ruleID, style string) string {
+	switch ruleID {
+	case "MD049":
+		switch style {
+		case "asterisk":
+			return "*"
+		case "underscore":
+			return "_"
+		}
+	case "MD050":
+		switch style {
+		case "asterisk":
+			return "**"
+		case "underscore":
+			return "__"
+		}
+	}
+	return style
+}
+
+func toMarkdownlintStyle(ruleID, style string) string {
+	switch ruleID {
+	case "MD049":
+		switch style {
+		case "*":
+			return "asterisk"
+		case "_":
+			return "underscore"
+		}
+	case "MD050":
+		switch style {
+		case "**":
+			return "asterisk"
+		case "__":
+			return "underscore"
+		}
+	}
+	return style
+}
+
+// ToMarkdownlint converts cfg into a markdownlint configuration object.
+// implemented lists the rule IDs mdmend checks; markdownlint rules outside
+// that set are disabled so both tools report on the same rules. A nil
+// implemented slice leaves them at markdownlint's defaults.
+func ToMarkdownlint(cfg *Config, implemented []string) map[string]interface{} {
+	out := map[string]interface{}{}
+
+	onlyMode := onlySelects(cfg.Only)
+	out["default"] = !onlyMode
+
+	for _, id := range markdownlintRuleIDs {
+		if implemented != nil && !containsRule(implemented, id) {
+			if !onlyMode {
+				out[id] = false
+			}
+			continue
+		}
+
+		rc := cfg.GetRuleConfig(id)
+		if !cfg.IsEnabled(id) {
+			if !onlyMode {
+				out[id] = false
+			}
+			continue
+		}
+
+		options := markdownlintOptions(id, rc, cfg)
+		switch {
+		case len(options) > 0:
+			out[id] = options
+		case onlyMode:
+			out[id] = true
+		}
+	}
+
+	return out
+}
+
+func markdownlintOptions(ruleID string, rc RuleConfig, cfg *Config) map[string]interface{} {
+	options := map[string]interface{}{}
+
+	switch ruleID {
+	case "MD003", "MD004", "MD029", "MD035", "MD046", "MD048", "MD049", "MD050", "MD060":
+		if rc.Style != "" {
+			options["style"] = toMarkdownlintStyle(ruleID, rc.Style)
+		}
+	case "MD007":
+		if rc.Indent > 0 {
+			options["indent"] = rc.Indent
+		}
+	case "MD010":
+		if rc.TabSize > 0 {
+			options["spaces_per_tab"] = rc.TabSize
+		} else if cfg.TabSize > 0 && cfg.TabSize != 4 {
+			options["spaces_per_tab"] = cfg.TabSize
+		}
+	case "MD013":
+		if rc.LineLength > 0 {
+			options["line_length"] = rc.LineLength
+		}
+		if rc.CodeBlocks != nil {
+			options["code_blocks"] = *rc.CodeBlocks
+		}
+		if rc.Tables != nil {
+			options["tables"] = *rc.Tables
+		}
+	case "MD024":
+		if rc.AllowDifferentNesting != nil {
+			options["siblings_only"] = *rc.AllowDifferentNesting
+		}
+	case "MD025", "MD041":
+		if rc.Level > 0 {
+			options["level"] = rc.Level
+		}
+		if rc.FrontMatter != nil && !*rc.FrontMatter {
+			options["front_matter_title"] = ""
+		}
+	case "MD026", "MD036":
+		if rc.Punctuation != "" {
+			options["punctuation"] = rc.Punctuation
+		}
+	case "MD033":
+		if len(rc.AllowedTags) > 0 {
+			options["allowed_elements"] = rc.AllowedTags
+		}
+	case "MD043":
+		if len(rc.Headings) > 0 {
+			options["headings"] = rc.Headings
+		}
+	case "MD044":
+		if len(rc.Names) > 0 {
+			options["names"] = rc.Names
+		}
+		if rc.CodeBlocks != nil {
+			options["code_blocks"] = *rc.CodeBlocks
+		}
+	case "MD059":
+		if len(rc.ProhibitedTexts) > 0 {
+			options["prohibited_texts"] = rc.ProhibitedTexts
+		}
+	}
+
+	return options
+}
+
+// MarkdownlintExportWarnings lists settings in cfg that have no markdownlint
+// equivalent and are dropped by ToMarkdownlint.
+func MarkdownlintExportWarnings(cfg *Config) []string {
+	var warnings []string
+
+	if len(cfg.Ignore) > 0 {
+		warnings = append(warnings, "ignore patterns are not part of markdownlint config; move them to .markdownlintignore or markdownlint-cli2 \"ignores\"")
+	}
+	if NormalizeFlavor(cfg.Flavor) != FlavorStandard || len(cfg.PerFileFlavor) > 0 {
+		warnings = append(warnings, "flavor and per_file_flavor have no markdownlint equivalent")
+	}
+	if cfg.SlugStyle != "" {
+		warnings = append(warnings, "slug_style has no markdownlint equivalent")
+	}
+	if cfg.Aggressive {
+		warnings = append(warnings, "aggressive mode has no markdownlint equivalent")
+	}
+	if cfg.FixLevel != "" {
+		warnings = append(warnings, "fix_level has no markdownlint equivalent")
+	}
+	var noFix []string
+	for id := range cfg.Rules {
+		if !cfg.FixEnabled(id) {
+			noFix = append(noFix, id)
+		}
+	}
+	if len(noFix) > 0 {
+		sort.Strings(noFix)
+		warnings = append(warnings, fmt.Sprintf("fix: false (%s) has no markdownlint equivalent", strings.Join(noFix, ", ")))
+	}
+	if cfg.MaxFileSize != "" || cfg.MaxDepth > 0 || cfg.FollowSymlinks {
+		warnings = append(warnings, "max_file_size, max_depth and follow_symlinks have no markdownlint equivalent")
+	}
+	if len(cfg.Extensions) > 0 || len(cfg.Include) > 0 {
+		warnings = append(warnings, "extensions and include are not part of markdownlint config; pass matching globs to markdownlint-cli2 instead")
+	}
+	defaults := Default()
+	if rc, def := cfg.GetRuleConfig("MD034"), defaults.GetRuleConfig("MD034"); (rc.Style != "" && rc.Style != def.Style) || len(rc.SkipPatterns) > 0 {
+		warnings = append(warnings, "MD034 style and skip_patterns only affect mdmend fixes")
+	}
+	if rc, def := cfg.GetRuleConfig("MD040"), defaults.GetRuleConfig("MD040"); (rc.Fallback != "" && rc.Fallback != def.Fallback) || (rc.Confidence > 0 && rc.Confidence != def.Confidence) {
+		warnings = append(warnings, "MD040 fallback and confidence only affect mdmend language inference")
+	}
+	if rc := cfg.GetRuleConfig("MD054"); rc.Style != "" && rc.Style != "consistent" {
+		warnings = append(warnings, fmt.Sprintf("MD054 style %q cannot be expressed with markdownlint's per-style switches", rc.Style))
+	}
+	if rc := cfg.GetRuleConfig("MD013"); rc.Reflow != "" {
+		warnings = append(warnings, "MD013 reflow only affects mdmend fixes")
+	}
+	if rc := cfg.GetRuleConfig("MD059"); len(rc.Languages) > 0 {
+		warnings = append(warnings, "MD059 languages only affect mdmend; list the phrases in prohibited_texts instead")
+	}
+
+	return warnings
+}
+
+func ToMarkdownlintJSON(cfg *Config, implemented []string) ([]byte, error) {
+	data, err := json.MarshalIndent(ToMarkdownlint(cfg, implemented), "", "  ")
+	if err != nil {
+		return nil, err
+	}
+	return append(data, '\n'), nil
+}
+
+func ToMarkdownlintYAML(cfg *Config, implemented []string) ([]byte, error) {
+	return yaml.Marshal(ToMarkdownlint(cfg, implemented))
+}
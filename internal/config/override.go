@@ -0,0 +1,174 @@
+package config
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+const EnvPrefix = "MDMEND_"
+
+var (
+	overrideRuleIDRegex = regexp.MustCompile(`^MD\d{3}$`)
+	envOverrideRegex    = regexp.MustCompile(`^MDMEND_(MD\d{3})_([A-Z0-9_]+)$`)
+)
+
+type OverrideError struct {
+	Source string
+	Rule   string
+	Key    string
+	Err    error
+}
+
+func (e *OverrideError) Error() string {
+	target := e.Rule
+	if e.Key != "" {
+		target += "." + e.Key
+	}
+	if e.Source != "" {
+		return e.Source + ": " + target + ": " + e.Err.Error()
+	}
+	return target + ": " + e.Err.Error()
+}
+
+func (e *OverrideError) Unwrap() error {
+	return e.Err
+}
+
+// ApplyOverride applies a single "RULE.key=value" expression to cfg, as used
+// by --set. key is the rule option's YAML name, e.g. MD013.line_length=100.
+func ApplyOverride(cfg *Config, expr string) error {
+	target, value, ok := strings.Cut(expr, "=")
+	if !ok {
+		return fmt.Errorf("invalid override %q: want RULE.key=value", expr)
+	}
+	ruleID, key, ok := strings.Cut(strings.TrimSpace(target), ".")
+	if !ok {
+		return fmt.Errorf("invalid override %q: want RULE.key=value", expr)
+	}
+	return SetRuleOption(cfg, ruleID, key, value)
+}
+
+func ApplyOverrides(cfg *Config, exprs []string) error {
+	for _, expr := range exprs {
+		if err := ApplyOverride(cfg, expr); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// ApplyEnvOverrides applies MDMEND_<RULE>_<KEY>=value entries from environ,
+// e.g. MDMEND_MD013_LINE_LENGTH=100. Entries are applied in sorted order so
+// the result does not depend on environment ordering.
+func ApplyEnvOverrides(cfg *Config, environ []string) error {
+	var entries []string
+	for _, kv := range environ {
+		if strings.HasPrefix(kv, EnvPrefix+"MD") {
+			entries = append(entries, kv)
+		}
+	}
+	sort.Strings(entries)
+
+	for _, kv := range entries {
+		name, value, _ := strings.Cut(kv, "=")
+		m := envOverrideRegex.FindStringSubmatch(name)
+		if m == nil {
+			continue
+		}
+		if err := SetRuleOption(cfg, m[1], strings.ToLower(m[2]), value); err != nil {
+			var oe *OverrideError
+			if errors.As(err, &oe) {
+				oe.Source = name
+			}
+			return err
+		}
+	}
+	return nil
+}
+
+// SetRuleOption parses value according to the type of the RuleConfig field
+// named key and stores it in cfg.Rules[ruleID].
+func SetRuleOption(cfg *Config, ruleID, key, value string) error {
+	ruleID = strings.ToUpper(strings.TrimSpace(ruleID))
+	key = strings.ToLower(strings.TrimSpace(key))
+	value = strings.TrimSpace(value)
+
+	if !overrideRuleIDRegex.MatchString(ruleID) {
+		return &OverrideError{Rule: ruleID, Key: key, Err: fmt.Errorf("invalid rule ID")}
+	}
+
+	idx, ok := ruleOptionFields()[key]
+	if !ok {
+		return &OverrideError{Rule: ruleID, Key: key, Err: fmt.Errorf("unknown option (valid: %s)", strings.Join(RuleOptionKeys(), ", "))}
+	}
+
+	if cfg.Rules == nil {
+		cfg.Rules = make(map[string]RuleConfig)
+	}
+	rc := cfg.Rules[ruleID]
+	field := reflect.ValueOf(&rc).Elem().Field(idx)
+
+	switch field.Kind() {
+	case reflect.String:
+		field.SetString(value)
+	case reflect.Int:
+		n, err := strconv.Atoi(value)
+		if err != nil {
+			return &OverrideError{Rule: ruleID, Key: key, Err: fmt.Errorf("%q is not an integer", value)}
+		}
+		field.SetInt(int64(n))
+	case reflect.Float64:
+		f, err := strconv.ParseFloat(value, 64)
+		if err != nil {
+			return &OverrideError{Rule: ruleID, Key: key, Err: fmt.Errorf("%q is not a number", value)}
+		}
+		field.SetFloat(f)
+	case reflect.Ptr:
+		b, err := strconv.ParseBool(value)
+		if err != nil {
+			return &OverrideError{Rule: ruleID, Key: key, Err: fmt.Errorf("%q is not a boolean", value)}
+		}
+		field.Set(reflect.ValueOf(&b))
+	case reflect.Slice:
+		items := []string{}
+		for _, item := range strings.Split(value, ",") {
+			if item = strings.TrimSpace(item); item != "" {
+				items = append(items, item)
+			}
+		}
+		field.Set(reflect.ValueOf(items))
+	default:
+		return &OverrideError{Rule: ruleID, Key: key, Err: fmt.Errorf("unsupported option type %s", field.Type())}
+	}
+
+	cfg.Rules[ruleID] = rc
+	return nil
+}
+
+func ruleOptionFields() map[string]int {
+	fields := make(map[string]int)
+	t := reflect.TypeOf(RuleConfig{})
+	for i := 0; i < t.NumField(); i++ {
+		tag := strings.Split(t.Field(i).Tag.Get("yaml"), ",")[0]
+		if tag != "" && tag != "-" {
+			fields[tag] = i
+		}
+	}
+	return fields
+}
+
+// RuleOptionKeys returns the option names accepted by SetRuleOption.
+func RuleOptionKeys() []string {
+	fields := ruleOptionFields()
+	keys := make([]string, 0, len(fields))
+	for k := range fields {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
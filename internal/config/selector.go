@@ -0,0 +1,160 @@
+package config
+
+import (
+	"path"
+	"strings"
+)
+
+var ruleLabels func(ruleID string) []string
+
+// SetRuleLabels registers the lookup that returns a rule's aliases and tags.
+// The rules package installs it so rule selectors can name rules by alias
+// (no-bare-urls) or tag (links) as well as by ID.
+func SetRuleLabels(fn func(ruleID string) []string) {
+	ruleLabels = fn
+}
+
+type SelectionOp int
+
+const (
+	SelectEnable SelectionOp = iota
+	SelectDisable
+	SelectReset
+)
+
+// ParseSelection splits a selection expression into its operation and
+// selector: "MD013" or "+MD013" enables, "~MD013" or "-MD013" disables and
+// "=MD013" resets the rule to its built-in default.
+func ParseSelection(expr string) (SelectionOp, string) {
+	expr = strings.TrimSpace(expr)
+	if expr == "" {
+		return SelectEnable, ""
+	}
+	switch expr[0] {
+	case '~', '-':
+		return SelectDisable, strings.TrimSpace(expr[1:])
+	case '=':
+		return SelectReset, strings.TrimSpace(expr[1:])
+	case '+':
+		return SelectEnable, strings.TrimSpace(expr[1:])
+	}
+	return SelectEnable, expr
+}
+
+// MatchesRule reports whether selector names ruleID directly, by alias, or by
+// tag. Matching is case-insensitive and selectors may use * and ? wildcards,
+// e.g. MD0*.
+func MatchesRule(selector, ruleID string) bool {
+	selector = strings.ToLower(strings.TrimSpace(selector))
+	if selector == "" || ruleID == "" {
+		return false
+	}
+	if matchSelector(selector, strings.ToLower(ruleID)) {
+		return true
+	}
+	if ruleLabels == nil {
+		return false
+	}
+	for _, label := range ruleLabels(ruleID) {
+		if matchSelector(selector, strings.ToLower(label)) {
+			return true
+		}
+	}
+	return false
+}
+
+func matchSelector(selector, name string) bool {
+	if !strings.ContainsAny(selector, "*?") {
+		return selector == name
+	}
+	ok, err := path.Match(selector, name)
+	return err == nil && ok
+}
+
+func matchesAny(selectors []string, ruleID string) bool {
+	for _, sel := range selectors {
+		if MatchesRule(sel, ruleID) {
+			return true
+		}
+	}
+	return false
+}
+
+// IsEnabled resolves whether ruleID runs under c. Rules are on unless turned
+// off, and each later source overrides the earlier ones:
+//
+//  1. disable:
+//  2. rules.<ID>.enabled
+//  3. enable:
+//  4. only: (when it has a positive entry, only the selected rules run; a
+//     list of ~ exclusions only turns rules off)
+//  5. select: expressions, applied in order (--rules on the CLI)
+func (c *Config) IsEnabled(ruleID string) bool {
+	enabled := !matchesAny(c.Disable, ruleID)
+
+	if rc, ok := c.Rules[ruleID]; ok && rc.Enabled != nil {
+		enabled = *rc.Enabled
+	}
+	if matchesAny(c.Enable, ruleID) {
+		enabled = true
+	}
+	if len(c.Only) > 0 {
+		enabled = selectsRule(c.Only, ruleID, enabled)
+	}
+
+	for _, expr := range c.Select {
+		op, sel := ParseSelection(expr)
+		if !MatchesRule(sel, ruleID) {
+			continue
+		}
+		switch op {
+		case SelectEnable:
+			enabled = true
+		case SelectDisable:
+			enabled = false
+		case SelectReset:
+			enabled = DefaultEnabled(ruleID)
+		}
+	}
+	return enabled
+}
+
+// DefaultEnabled reports whether ruleID runs under the built-in defaults.
+func DefaultEnabled(ruleID string) bool {
+	return Default().IsEnabled(ruleID)
+}
+
+// selectsRule evaluates a selector list such as [links ~MD057]: a rule is
+// selected when a positive entry matches it and no ~-prefixed entry matches
+// it. A list of only ~ entries keeps enabled for the rules it does not
+// exclude.
+func selectsRule(selectors []string, ruleID string, enabled bool) bool {
+	selected := false
+	for _, expr := range selectors {
+		op, sel := ParseSelection(expr)
+		if op == SelectDisable {
+			if MatchesRule(sel, ruleID) {
+				return false
+			}
+			continue
+		}
+		if MatchesRule(sel, ruleID) {
+			selected = true
+		}
+	}
+	if !onlySelects(selectors) {
+		return enabled
+	}
+	return selected
+}
+
+// onlySelects reports whether an only: list has a positive entry, so that
+// the rules it does not match are off. A list of ~ exclusions does not.
+func onlySelects(selectors []string) bool {
+	for _, expr := range selectors {
+		if op, _ := ParseSelection(expr); op != SelectDisable {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,54 @@
+package config
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+var sizeUnits = []struct {
+	suffix string
+	scale  int64
+}{
+	{"GB", 1 << 30},
+	{"MB", 1 << 20},
+	{"KB", 1 << 10},
+	{"G", 1 << 30},
+	{"M", 1 << 20},
+	{"K", 1 << 10},
+	{"B", 1},
+}
+
+// ParseSize parses a byte size such as "512KB", "10MB" or "2048". Units are
+// binary (1KB = 1024 bytes) and case-insensitive. An empty string is 0.
+func ParseSize(s string) (int64, error) {
+	orig := s
+	s = strings.ToUpper(strings.TrimSpace(s))
+	if s == "" {
+		return 0, nil
+	}
+
+	scale := int64(1)
+	for _, unit := range sizeUnits {
+		if strings.HasSuffix(s, unit.suffix) {
+			s = strings.TrimSpace(strings.TrimSuffix(s, unit.suffix))
+			scale = unit.scale
+			break
+		}
+	}
+
+	n, err := strconv.ParseFloat(s, 64)
+	if err != nil || n < 0 {
+		return 0, fmt.Errorf("invalid size %q", orig)
+	}
+	return int64(n * float64(scale)), nil
+}
+
+// GetMaxFileSize returns max_file_size in bytes, 0 meaning unlimited.
+func (c *Config) GetMaxFileSize() (int64, error) {
+	size, err := ParseSize(c.MaxFileSize)
+	if err != nil {
+		return 0, fmt.Errorf("max_file_size: %w", err)
+	}
+	return size, nil
+}
@@ -4,6 +4,7 @@ import (
 	"strings"
 
 	"github.com/mohitmishra786/mdmend/internal/config"
+	"github.com/mohitmishra786/mdmend/internal/markdown"
 	"github.com/mohitmishra786/mdmend/internal/rules"
 )
 
@@ -26,17 +27,45 @@ type FixResult struct {
 	Fixes      int
 }
 
+// Change is one hunk of a rule's proposed edit to a document, offered to the
+// accept callback of FixFunc. Before is the document with the rule's earlier
+// accepted hunks applied and After adds this hunk; both use LF line endings.
+// Violations are those reported on the lines the hunk changes, and Hunk
+// counts from 1 to Hunks within the rule's edit.
+type Change struct {
+	Rule       rules.Rule
+	Path       string
+	Before     string
+	After      string
+	Violations []rules.Violation
+	Hunk       int
+	Hunks      int
+}
+
+// Fix applies every fixable rule allowed by the config. Rules see LF line
+// endings and no byte order mark; both are restored in the result, with line
+// endings converted when the config sets end_of_line.
 func (f *Fixer) Fix(content string, path string) FixResult {
+	return f.FixFunc(content, path, nil)
+}
+
+// FixFunc is like Fix but splits each rule's edit into hunks of changed
+// lines and applies a hunk only when accept returns true for it. A nil
+// accept applies every edit.
+func (f *Fixer) FixFunc(content string, path string, accept func(Change) bool) FixResult {
 	result := FixResult{
 		Content: content,
 	}
 
-	currentContent := content
+	text, enc := markdown.Decode(content)
+	enc = enc.WithEndOfLine(f.config.EndOfLine)
+
+	currentContent := text
 	changed := false
 	totalFixes := 0
 
 	for _, rule := range f.rules {
-		if !rule.Fixable() {
+		if !rules.CanFix(f.config, rule) {
 			continue
 		}
 
@@ -44,15 +73,28 @@ func (f *Fixer) Fix(content string, path string) FixResult {
 		if len(violations) > 0 {
 			fixResult := rule.Fix(currentContent, path)
 			if fixResult.Changed {
-				currentContent = strings.Join(fixResult.Lines, "\n")
+				fixed := strings.Join(fixResult.Lines, "\n")
+				fixes := len(violations)
+				if accept != nil && fixed != currentContent {
+					fixed, fixes = acceptHunks(rule, path, currentContent, fixed, violations, accept)
+					if fixed == currentContent {
+						continue
+					}
+				}
+				currentContent = fixed
 				changed = true
-				totalFixes += len(violations)
+				totalFixes += fixes
 			}
 		}
 	}
 
-	result.Changed = changed
-	result.Content = currentContent
+	// With end_of_line unset, documents no rule touched are returned as-is so
+	// mixed line endings are left alone.
+	eol := strings.ToLower(f.config.EndOfLine)
+	if changed || eol == markdown.EndOfLineLF || eol == markdown.EndOfLineCRLF {
+		result.Content = enc.Encode(currentContent)
+		result.Changed = result.Content != content
+	}
 	result.Fixes = totalFixes
 
 	return result
@@ -64,9 +106,13 @@ func (f *Fixer) FixWithDiff(content string, path string) (string, []rules.Violat
 }
 
 func (f *Fixer) Lint(content string, path string) []rules.Violation {
+	content, _ = markdown.Decode(content)
 	var allViolations []rules.Violation
 	for _, rule := range f.rules {
 		violations := rule.Lint(content, path)
+		if !rules.CanFix(f.config, rule) {
+			markUnfixable(violations)
+		}
 		allViolations = append(allViolations, violations...)
 	}
 	return allViolations
@@ -81,3 +127,11 @@ func DryRun(content string, path string, cfg *config.Config) (string, []rules.Vi
 	fixer := New(cfg)
 	return fixer.FixWithDiff(content, path)
 }
+
+// markUnfixable clears Fixable on violations of rules whose fixes the config
+// does not allow, so reports do not promise a fix that will not happen.
+func markUnfixable(violations []rules.Violation) {
+	for i := range violations {
+		violations[i].Fixable = false
+	}
+}
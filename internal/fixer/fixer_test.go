@@ -3,6 +3,7 @@ package fixer
 import (
 	"os"
 	"path/filepath"
+	"strings"
 	"testing"
 
 	"github.com/mohitmishra786/mdmend/internal/config"
@@ -212,3 +213,164 @@ func TestFixWithFixes(t *testing.T) {
 		t.Error("ApplyFixes() should count fixes")
 	}
 }
+
+func TestFixPreservesEncoding(t *testing.T) {
+	f := New(config.Default())
+
+	content := "\ufeff# Heading\r\n\r\nTrailing  \r\nText\r\n"
+	result := f.Fix(content, "test.md")
+	if !result.Changed {
+		t.Fatal("Fix() should remove trailing spaces")
+	}
+	want := "\ufeff# Heading\r\n\r\nTrailing\r\nText\r\n"
+	if result.Content != want {
+		t.Errorf("Fix() = %q, want %q", result.Content, want)
+	}
+
+	clean := "\ufeff# Heading\r\n\r\nText\r\n"
+	if result := f.Fix(clean, "test.md"); result.Changed {
+		t.Errorf("Fix() changed clean CRLF content to %q", result.Content)
+	}
+}
+
+func TestFixEndOfLine(t *testing.T) {
+	cfg := config.Default()
+	cfg.EndOfLine = "lf"
+	f := New(cfg)
+
+	result := f.Fix("# Heading\r\n\r\nText\r\n", "test.md")
+	if !result.Changed || result.Content != "# Heading\n\nText\n" {
+		t.Errorf("Fix() with end_of_line lf = %q, changed %v", result.Content, result.Changed)
+	}
+
+	cfg.EndOfLine = "crlf"
+	f = New(cfg)
+	result = f.Fix("# Heading\n\nText\n", "test.md")
+	if result.Content != "# Heading\r\n\r\nText\r\n" {
+		t.Errorf("Fix() with end_of_line crlf = %q", result.Content)
+	}
+}
+
+func TestPatcherPreservesModeAndRejectsInvalidUTF8(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "script.md")
+	if err := os.WriteFile(path, []byte("old\n"), 0600); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := AtomicWrite(path, []byte("new\n")); err != nil {
+		t.Fatal(err)
+	}
+	info, err := os.Stat(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if info.Mode().Perm() != 0600 {
+		t.Errorf("mode = %v, want 0600", info.Mode().Perm())
+	}
+
+	if err := AtomicWrite(path, []byte("caf\xe9\n")); err != ErrInvalidUTF8 {
+		t.Errorf("AtomicWrite() error = %v, want ErrInvalidUTF8", err)
+	}
+	if err := NewPatcher(path).AllowInvalidUTF8(true).Apply("caf\xe9\n"); err != nil {
+		t.Errorf("Apply() with AllowInvalidUTF8 error = %v", err)
+	}
+}
+
+func TestPatcherExpectDetectsConflict(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "a.md")
+	if err := os.WriteFile(path, []byte("original\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	p := NewPatcher(path).Expect([]byte("original\n"))
+	if err := os.WriteFile(path, []byte("edited elsewhere\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if err := p.Apply("fixed\n"); err != ErrConflict {
+		t.Fatalf("Apply() error = %v, want ErrConflict", err)
+	}
+	if data, _ := os.ReadFile(path); string(data) != "edited elsewhere\n" {
+		t.Errorf("file = %q, the concurrent edit should survive", data)
+	}
+	if matches, _ := filepath.Glob(filepath.Join(filepath.Dir(path), ".*.tmp")); len(matches) != 0 {
+		t.Errorf("temporary files left behind: %v", matches)
+	}
+
+	if err := NewPatcher(path).Expect([]byte("edited elsewhere\n")).Apply("fixed\n"); err != nil {
+		t.Errorf("Apply() error = %v", err)
+	}
+}
+
+func TestFixLevelAndRuleFix(t *testing.T) {
+	content := "# Heading\n\nTrailing  \n\n## Title.\n"
+
+	cfg := config.Default()
+	cfg.FixLevel = config.FixLevelSafe
+	result := New(cfg).Fix(content, "test.md")
+	if want := "# Heading\n\nTrailing\n\n## Title.\n"; result.Content != want {
+		t.Errorf("Fix() at safe level = %q, want %q", result.Content, want)
+	}
+
+	cfg = config.Default()
+	off := false
+	cfg.Rules["MD026"] = config.RuleConfig{Fix: &off}
+	f := New(cfg)
+	if result := f.Fix(content, "test.md"); strings.Contains(result.Content, "Title\n") {
+		t.Errorf("Fix() applied MD026 with fix: false: %q", result.Content)
+	}
+	for _, v := range f.Lint(content, "test.md") {
+		if v.Rule == "MD026" && v.Fixable {
+			t.Error("MD026 violations should not be fixable with fix: false")
+		}
+	}
+}
+
+func TestFixFunc(t *testing.T) {
+	content := "# Heading\n\nTrailing  \n\n## Title.\n"
+	f := New(config.Default())
+
+	var offered []string
+	result := f.FixFunc(content, "test.md", func(c Change) bool {
+		offered = append(offered, c.Rule.ID())
+		if c.Before == c.After {
+			t.Errorf("%s offered an empty change", c.Rule.ID())
+		}
+		return c.Rule.ID() == "MD009"
+	})
+	if want := "# Heading\n\nTrailing\n\n## Title.\n"; result.Content != want {
+		t.Errorf("FixFunc() = %q, want %q", result.Content, want)
+	}
+	if len(offered) != 2 {
+		t.Errorf("offered = %v, want MD009 and MD026", offered)
+	}
+}
+
+func TestFixFuncHunks(t *testing.T) {
+	content := "# Heading\n\nOne  \nTwo\n\nThree  \n\nFour  \n"
+	f := New(config.Default())
+
+	var hunks []Change
+	result := f.FixFunc(content, "test.md", func(c Change) bool {
+		if c.Rule.ID() != "MD009" {
+			return false
+		}
+		hunks = append(hunks, c)
+		return c.Hunk != 2
+	})
+	if want := "# Heading\n\nOne\nTwo\n\nThree  \n\nFour\n"; result.Content != want {
+		t.Errorf("FixFunc() = %q, want %q", result.Content, want)
+	}
+	if result.Fixes != 2 {
+		t.Errorf("Fixes = %d, want 2", result.Fixes)
+	}
+	if len(hunks) != 3 {
+		t.Fatalf("got %d MD009 hunks, want 3", len(hunks))
+	}
+	c := hunks[2]
+	if c.Hunks != 3 || len(c.Violations) != 1 || c.Violations[0].Line != 8 {
+		t.Errorf("hunk 3 = %+v", c)
+	}
+	if want := "--- test.md\n+++ test.md\n@@ -6,4 +6,4 @@\n Three  \n \n-Four  \n+Four\n \n"; c.Diff(2) != want {
+		t.Errorf("Diff() = %q, want %q", c.Diff(2), want)
+	}
+}
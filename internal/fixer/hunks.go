@@ -0,0 +1,162 @@
+package fixer
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/mohitmishra786/mdmend/internal/rules"
+	"github.com/sergi/go-diff/diffmatchpatch"
+)
+
+// hunk replaces lines [start, end) of a document with lines.
+type hunk struct {
+	start int
+	end   int
+	lines []string
+}
+
+// splitHunks returns the runs of changed lines that turn before into after,
+// separated by unchanged lines.
+func splitHunks(before, after []string) []hunk {
+	ids := make(map[string]rune)
+	encode := func(lines []string) []rune {
+		out := make([]rune, len(lines))
+		for i, line := range lines {
+			id, ok := ids[line]
+			if !ok {
+				// Private use code points and above, so that every line
+				// maps to one valid rune.
+				id = 0xE000 + rune(len(ids))
+				ids[line] = id
+			}
+			out[i] = id
+		}
+		return out
+	}
+
+	dmp := diffmatchpatch.New()
+	diffs := dmp.DiffMainRunes(encode(before), encode(after), false)
+
+	var hunks []hunk
+	var cur *hunk
+	b, a := 0, 0
+	for _, d := range diffs {
+		n := len([]rune(d.Text))
+		switch d.Type {
+		case diffmatchpatch.DiffEqual:
+			if cur != nil {
+				hunks = append(hunks, *cur)
+				cur = nil
+			}
+			b += n
+			a += n
+			continue
+		}
+		if cur == nil {
+			cur = &hunk{start: b, end: b}
+		}
+		if d.Type == diffmatchpatch.DiffDelete {
+			b += n
+			cur.end = b
+		} else {
+			cur.lines = append(cur.lines, after[a:a+n]...)
+			a += n
+		}
+	}
+	if cur != nil {
+		hunks = append(hunks, *cur)
+	}
+	return hunks
+}
+
+// applyHunks returns lines with the hunks, which are in document order,
+// applied.
+func applyHunks(lines []string, hunks []hunk) []string {
+	out := make([]string, 0, len(lines))
+	last := 0
+	for _, h := range hunks {
+		out = append(out, lines[last:h.start]...)
+		out = append(out, h.lines...)
+		last = h.end
+	}
+	return append(out, lines[last:]...)
+}
+
+// covers reports whether v is on a line h changes, or next to the lines it
+// inserts.
+func (h hunk) covers(v rules.Violation) bool {
+	return v.Line >= h.start && v.Line <= h.end+1
+}
+
+// acceptHunks offers each hunk of a rule's edit from before to after to
+// accept, and returns before with the accepted hunks applied and the number
+// of violations they fix.
+func acceptHunks(rule rules.Rule, path, before, after string, violations []rules.Violation, accept func(Change) bool) (string, int) {
+	lines := strings.Split(before, "\n")
+	hunks := splitHunks(lines, strings.Split(after, "\n"))
+
+	var accepted []hunk
+	fixed := make(map[int]bool)
+	current := before
+	for i, h := range hunks {
+		candidate := append(append([]hunk(nil), accepted...), h)
+		proposed := strings.Join(applyHunks(lines, candidate), "\n")
+		c := Change{
+			Rule:   rule,
+			Path:   path,
+			Before: current,
+			After:  proposed,
+			Hunk:   i + 1,
+			Hunks:  len(hunks),
+		}
+		for _, v := range violations {
+			if h.covers(v) {
+				c.Violations = append(c.Violations, v)
+			}
+		}
+		if !accept(c) {
+			continue
+		}
+		accepted = candidate
+		current = proposed
+		for j, v := range violations {
+			if h.covers(v) {
+				fixed[j] = true
+			}
+		}
+	}
+	if len(accepted) == len(hunks) {
+		return after, len(violations)
+	}
+	return current, len(fixed)
+}
+
+// Diff returns the change as a unified diff hunk with up to context
+// unchanged lines around it.
+func (c Change) Diff(context int) string {
+	before := strings.Split(c.Before, "\n")
+	after := strings.Split(c.After, "\n")
+
+	var b strings.Builder
+	fmt.Fprintf(&b, "--- %s\n+++ %s\n", c.Path, c.Path)
+	offset := 0
+	for _, h := range splitHunks(before, after) {
+		from := max(h.start-context, 0)
+		to := min(h.end+context, len(before))
+		fmt.Fprintf(&b, "@@ -%d,%d +%d,%d @@\n", from+1, to-from, from+offset+1, to-from+len(h.lines)-(h.end-h.start))
+		for _, line := range before[from:h.start] {
+			fmt.Fprintf(&b, " %s\n", line)
+		}
+		for _, line := range before[h.start:h.end] {
+			fmt.Fprintf(&b, "-%s\n", line)
+		}
+		for _, line := range h.lines {
+			fmt.Fprintf(&b, "+%s\n", line)
+		}
+		for _, line := range before[h.end:to] {
+			fmt.Fprintf(&b, " %s\n", line)
+		}
+		offset += len(h.lines) - (h.end - h.start)
+	}
+	return b.String()
+}
@@ -1,19 +1,52 @@
 package fixer
 
 import (
+	"bytes"
+	"crypto/sha256"
+	"errors"
 	"os"
 	"path/filepath"
+	"unicode/utf8"
 )
 
+var ErrInvalidUTF8 = errors.New("content is not valid UTF-8; refusing to write")
+
+// ErrConflict is returned by Apply when the file no longer matches the
+// content passed to Expect, e.g. because an editor saved it mid-run.
+var ErrConflict = errors.New("file changed since it was read; not overwriting")
+
 type Patcher struct {
-	path string
+	path             string
+	allowInvalidUTF8 bool
+	expected         *[sha256.Size]byte
 }
 
 func NewPatcher(path string) *Patcher {
 	return &Patcher{path: path}
 }
 
+// AllowInvalidUTF8 lets Apply write content that is not valid UTF-8.
+func (p *Patcher) AllowInvalidUTF8(allow bool) *Patcher {
+	p.allowInvalidUTF8 = allow
+	return p
+}
+
+// Expect makes Apply fail with ErrConflict unless the file still holds
+// original just before it is replaced.
+func (p *Patcher) Expect(original []byte) *Patcher {
+	sum := sha256.Sum256(original)
+	p.expected = &sum
+	return p
+}
+
+// Apply atomically replaces the file with content, keeping the existing
+// file's permissions. The new content and the directory entry are synced
+// to disk before Apply returns.
 func (p *Patcher) Apply(content string) error {
+	if !p.allowInvalidUTF8 && !utf8.ValidString(content) {
+		return ErrInvalidUTF8
+	}
+
 	dir := filepath.Dir(p.path)
 	if dir != "" {
 		if err := os.MkdirAll(dir, 0755); err != nil {
@@ -21,8 +54,23 @@ func (p *Patcher) Apply(content string) error {
 		}
 	}
 
-	tmpPath := p.path + ".tmp"
-	if err := os.WriteFile(tmpPath, []byte(content), 0644); err != nil {
+	mode := os.FileMode(0644)
+	if info, err := os.Stat(p.path); err == nil {
+		mode = info.Mode().Perm()
+	}
+
+	tmp, err := os.CreateTemp(dir, "."+filepath.Base(p.path)+".*.tmp")
+	if err != nil {
+		return err
+	}
+	tmpPath := tmp.Name()
+	if err := writeSynced(tmp, []byte(content), mode); err != nil {
+		_ = os.Remove(tmpPath)
+		return err
+	}
+
+	if err := p.checkUnchanged(); err != nil {
+		_ = os.Remove(tmpPath)
 		return err
 	}
 
@@ -31,6 +79,52 @@ func (p *Patcher) Apply(content string) error {
 		return err
 	}
 
+	return syncDir(dir)
+}
+
+func (p *Patcher) checkUnchanged() error {
+	if p.expected == nil {
+		return nil
+	}
+	current, err := os.ReadFile(p.path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return ErrConflict
+		}
+		return err
+	}
+	if sum := sha256.Sum256(current); !bytes.Equal(sum[:], p.expected[:]) {
+		return ErrConflict
+	}
+	return nil
+}
+
+func writeSynced(f *os.File, content []byte, mode os.FileMode) error {
+	if _, err := f.Write(content); err != nil {
+		_ = f.Close()
+		return err
+	}
+	// CreateTemp uses 0600; match the original file instead.
+	if err := f.Chmod(mode); err != nil {
+		_ = f.Close()
+		return err
+	}
+	if err := f.Sync(); err != nil {
+		_ = f.Close()
+		return err
+	}
+	return f.Close()
+}
+
+// syncDir flushes a directory entry after a rename. Platforms that cannot
+// open or sync directories are ignored.
+func syncDir(dir string) error {
+	d, err := os.Open(dir)
+	if err != nil {
+		return nil
+	}
+	defer d.Close()
+	_ = d.Sync()
 	return nil
 }
 
@@ -0,0 +1,208 @@
+// Package journal keeps the original content of files rewritten by a fix
+// run so that the run can be undone.
+package journal
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"os"
+	"path/filepath"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/mohitmishra786/mdmend/internal/fixer"
+)
+
+const version = 1
+
+// ErrEmpty is returned by Load when there is no fix run to undo.
+var ErrEmpty = errors.New("no fix run to undo")
+
+// Entry is one file rewritten by a fix run. Original holds the bytes before
+// the fix and Fixed the SHA-256 of what was written in their place.
+type Entry struct {
+	Path     string `json:"path"`
+	Original []byte `json:"original"`
+	Fixed    string `json:"fixed"`
+}
+
+type file struct {
+	Version int       `json:"version"`
+	Created time.Time `json:"created"`
+	Entries []Entry   `json:"entries"`
+}
+
+type Journal struct {
+	path    string
+	created time.Time
+	entries map[string]Entry
+	mu      sync.Mutex
+}
+
+// Conflict is a journal entry that Restore did not apply because the file
+// was edited after the fix run.
+type Conflict struct {
+	Path string
+	Err  error
+}
+
+func DefaultPath() (string, error) {
+	dir, err := os.UserCacheDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(dir, "mdmend", "journal.json"), nil
+}
+
+// New starts an empty journal for a fix run. Nothing is written to path
+// until Save.
+func New(path string) *Journal {
+	return &Journal{
+		path:    path,
+		created: time.Now(),
+		entries: make(map[string]Entry),
+	}
+}
+
+// Load reads the journal at path, or at DefaultPath when path is empty.
+func Load(path string) (*Journal, error) {
+	if path == "" {
+		var err error
+		path, err = DefaultPath()
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, ErrEmpty
+		}
+		return nil, err
+	}
+
+	var f file
+	if err := json.Unmarshal(data, &f); err != nil {
+		return nil, err
+	}
+	if len(f.Entries) == 0 {
+		return nil, ErrEmpty
+	}
+
+	j := New(path)
+	j.created = f.Created
+	for _, e := range f.Entries {
+		j.entries[e.Path] = e
+	}
+	return j, nil
+}
+
+func (j *Journal) Path() string {
+	return j.path
+}
+
+func (j *Journal) Created() time.Time {
+	return j.created
+}
+
+func (j *Journal) Len() int {
+	j.mu.Lock()
+	defer j.mu.Unlock()
+	return len(j.entries)
+}
+
+// Entries returns the recorded files sorted by path.
+func (j *Journal) Entries() []Entry {
+	j.mu.Lock()
+	defer j.mu.Unlock()
+
+	entries := make([]Entry, 0, len(j.entries))
+	for _, e := range j.entries {
+		entries = append(entries, e)
+	}
+	sort.Slice(entries, func(a, b int) bool { return entries[a].Path < entries[b].Path })
+	return entries
+}
+
+// Record notes that path was rewritten from original to fixed. A file
+// recorded twice keeps its first original.
+func (j *Journal) Record(path string, original, fixed []byte) error {
+	abs, err := filepath.Abs(path)
+	if err != nil {
+		return err
+	}
+
+	j.mu.Lock()
+	defer j.mu.Unlock()
+	e, ok := j.entries[abs]
+	if !ok {
+		e = Entry{Path: abs, Original: append([]byte(nil), original...)}
+	}
+	e.Fixed = hash(fixed)
+	j.entries[abs] = e
+	return nil
+}
+
+func (j *Journal) Save() error {
+	data, err := json.Marshal(file{
+		Version: version,
+		Created: j.created,
+		Entries: j.Entries(),
+	})
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(j.path), 0o755); err != nil {
+		return err
+	}
+	// The journal holds copies of user files; keep it private.
+	return os.WriteFile(j.path, data, 0o600)
+}
+
+// Restore writes every original back. Files edited since the fix run are
+// reported as conflicts and left alone unless force is set. The journal is
+// removed once nothing is left to restore.
+func (j *Journal) Restore(force bool) (restored []string, conflicts []Conflict, err error) {
+	for _, e := range j.Entries() {
+		current, readErr := os.ReadFile(e.Path)
+		if readErr != nil && !os.IsNotExist(readErr) {
+			conflicts = append(conflicts, Conflict{Path: e.Path, Err: readErr})
+			continue
+		}
+		if !force && (readErr != nil || hash(current) != e.Fixed) {
+			conflicts = append(conflicts, Conflict{Path: e.Path, Err: fixer.ErrConflict})
+			continue
+		}
+
+		p := fixer.NewPatcher(e.Path).AllowInvalidUTF8(true)
+		if readErr == nil {
+			p.Expect(current)
+		}
+		if err := p.Apply(string(e.Original)); err != nil {
+			conflicts = append(conflicts, Conflict{Path: e.Path, Err: err})
+			continue
+		}
+		restored = append(restored, e.Path)
+
+		j.mu.Lock()
+		delete(j.entries, e.Path)
+		j.mu.Unlock()
+	}
+
+	if j.Len() == 0 {
+		if err := os.Remove(j.path); err != nil && !os.IsNotExist(err) {
+			return restored, conflicts, err
+		}
+		return restored, conflicts, nil
+	}
+	return restored, conflicts, j.Save()
+}
+
+func hash(content []byte) string {
+	sum := sha256.Sum256(content)
+	return hex.EncodeToString(sum[:])
+}
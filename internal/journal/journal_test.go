@@ -0,0 +1,80 @@
+package journal
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestJournalRestore(t *testing.T) {
+	dir := t.TempDir()
+	a := filepath.Join(dir, "a.md")
+	b := filepath.Join(dir, "b.md")
+	if err := os.WriteFile(a, []byte("fixed a\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(b, []byte("edited b\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	j := New(filepath.Join(dir, "journal.json"))
+	if err := j.Record(a, []byte("orig a  \n"), []byte("fixed a\n")); err != nil {
+		t.Fatal(err)
+	}
+	if err := j.Record(b, []byte("orig b  \n"), []byte("fixed b\n")); err != nil {
+		t.Fatal(err)
+	}
+	if err := j.Save(); err != nil {
+		t.Fatal(err)
+	}
+
+	loaded, err := Load(j.Path())
+	if err != nil {
+		t.Fatal(err)
+	}
+	restored, conflicts, err := loaded.Restore(false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(restored) != 1 || restored[0] != a {
+		t.Errorf("restored = %v, want [%s]", restored, a)
+	}
+	if len(conflicts) != 1 || conflicts[0].Path != b {
+		t.Errorf("conflicts = %v, want b.md", conflicts)
+	}
+	if data, _ := os.ReadFile(a); string(data) != "orig a  \n" {
+		t.Errorf("a.md = %q, want the original", data)
+	}
+	if data, _ := os.ReadFile(b); string(data) != "edited b\n" {
+		t.Errorf("b.md = %q, edits after the fix run should be kept", data)
+	}
+
+	loaded, err = Load(j.Path())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, _, err := loaded.Restore(true); err != nil {
+		t.Fatal(err)
+	}
+	if data, _ := os.ReadFile(b); string(data) != "orig b  \n" {
+		t.Errorf("b.md = %q, want the original after --force", data)
+	}
+	if _, err := Load(j.Path()); !errors.Is(err, ErrEmpty) {
+		t.Errorf("Load() after full restore error = %v, want ErrEmpty", err)
+	}
+}
+
+func TestJournalRecordKeepsFirstOriginal(t *testing.T) {
+	j := New(filepath.Join(t.TempDir(), "journal.json"))
+	_ = j.Record("a.md", []byte("first"), []byte("second"))
+	_ = j.Record("a.md", []byte("second"), []byte("third"))
+
+	entries := j.Entries()
+	if len(entries) != 1 || string(entries[0].Original) != "first" {
+		t.Errorf("Entries() = %+v, want the first original", entries)
+	}
+	if entries[0].Fixed != hash([]byte("third")) {
+		t.Error("Fixed should hash the latest content")
+	}
+}
@@ -0,0 +1,124 @@
+package linkcheck
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+)
+
+// Entry is a stored result.
+type Entry struct {
+	Status    int       `json:"status"`
+	Err       string    `json:"error,omitempty"`
+	CheckedAt time.Time `json:"checked_at"`
+}
+
+// Cache stores results on disk so that URLs are checked at most once per
+// TTL. Network errors, rate limiting and server errors are not stored.
+type Cache struct {
+	path    string
+	ttl     time.Duration
+	entries map[string]Entry
+	mu      sync.Mutex
+	dirty   bool
+	now     func() time.Time
+}
+
+func DefaultCachePath() (string, error) {
+	dir, err := os.UserCacheDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(dir, "mdmend", "links.json"), nil
+}
+
+// LoadCache reads the cache at path, or the default path when path is
+// empty. A ttl of zero uses DefaultCacheTTL.
+func LoadCache(path string, ttl time.Duration) (*Cache, error) {
+	if path == "" {
+		var err error
+		path, err = DefaultCachePath()
+		if err != nil {
+			return nil, err
+		}
+	}
+	if ttl <= 0 {
+		ttl = DefaultCacheTTL
+	}
+
+	c := &Cache{path: path, ttl: ttl, entries: make(map[string]Entry), now: time.Now}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return c, nil
+		}
+		return nil, err
+	}
+	if err := json.Unmarshal(data, &c.entries); err != nil {
+		return nil, err
+	}
+	return c, nil
+}
+
+func (c *Cache) Path() string {
+	return c.path
+}
+
+// Get returns the stored result for u if it is younger than the TTL.
+func (c *Cache) Get(u string) (Result, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	e, ok := c.entries[u]
+	if !ok || c.now().Sub(e.CheckedAt) >= c.ttl {
+		return Result{}, false
+	}
+	return Result{URL: u, Status: e.Status, Err: e.Err, Cached: true}, true
+}
+
+func (c *Cache) Put(r Result) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.entries[r.URL] = Entry{Status: r.Status, Err: r.Err, CheckedAt: c.now().UTC()}
+	c.dirty = true
+}
+
+// Save writes the cache, dropping expired entries.
+func (c *Cache) Save() error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if !c.dirty {
+		return nil
+	}
+
+	now := c.now()
+	for u, e := range c.entries {
+		if now.Sub(e.CheckedAt) >= c.ttl {
+			delete(c.entries, u)
+		}
+	}
+	if err := os.MkdirAll(filepath.Dir(c.path), 0o755); err != nil {
+		return err
+	}
+	data, err := json.MarshalIndent(c.entries, "", "  ")
+	if err != nil {
+		return err
+	}
+	if err := os.WriteFile(c.path, data, 0o644); err != nil {
+		return err
+	}
+	c.dirty = false
+	return nil
+}
+
+func (c *Cache) Clear() error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.entries = make(map[string]Entry)
+	c.dirty = false
+	if err := os.Remove(c.path); err != nil && !os.IsNotExist(err) {
+		return err
+	}
+	return nil
+}
@@ -0,0 +1,325 @@
+// Package linkcheck checks that external URLs resolve. It never runs on its
+// own: callers opt in and may inject the HTTP client, so checks can be
+// pointed at a local server in tests.
+package linkcheck
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+const (
+	DefaultTimeout      = 10 * time.Second
+	DefaultConcurrency  = 8
+	DefaultHostInterval = 200 * time.Millisecond
+	DefaultRetries      = 2
+	DefaultBackoff      = 500 * time.Millisecond
+	DefaultCacheTTL     = 24 * time.Hour
+
+	userAgent = "mdmend-linkcheck (+https://github.com/mohitmishra786/mdmend)"
+)
+
+// Doer sends HTTP requests. *http.Client implements it.
+type Doer interface {
+	Do(req *http.Request) (*http.Response, error)
+}
+
+// Options configure a Checker. Zero values take the defaults above, except
+// Retries, which is used as given; set it to DefaultRetries for the usual
+// behaviour.
+type Options struct {
+	Client       Doer
+	Timeout      time.Duration
+	Concurrency  int
+	HostInterval time.Duration // minimum gap between requests to one host
+	Retries      int
+	Backoff      time.Duration // wait before the first retry, doubled after each
+	// Allow, when set, limits checks to matching URLs; Deny skips matching
+	// URLs. See Match for the pattern syntax.
+	Allow []string
+	Deny  []string
+	Cache *Cache
+}
+
+// Result is the outcome of checking one URL. Status is 0 when no response
+// was received.
+type Result struct {
+	URL    string
+	Status int
+	Err    string
+	Cached bool
+}
+
+// OK reports whether the URL resolved to a 2xx or 3xx response.
+func (r Result) OK() bool {
+	return r.Err == "" && r.Status >= 200 && r.Status < 400
+}
+
+// Reason describes a failed result, such as "404 Not Found".
+func (r Result) Reason() string {
+	if r.Err != "" {
+		return r.Err
+	}
+	if text := http.StatusText(r.Status); text != "" {
+		return fmt.Sprintf("%d %s", r.Status, text)
+	}
+	return strconv.Itoa(r.Status)
+}
+
+// Checker checks URLs, spacing out requests to each host. A Checker may be
+// shared by goroutines and across calls to Check.
+type Checker struct {
+	opts Options
+
+	mu   sync.Mutex
+	next map[string]time.Time
+}
+
+func New(opts Options) *Checker {
+	if opts.Timeout <= 0 {
+		opts.Timeout = DefaultTimeout
+	}
+	if opts.Client == nil {
+		opts.Client = &http.Client{Timeout: opts.Timeout}
+	}
+	if opts.Concurrency <= 0 {
+		opts.Concurrency = DefaultConcurrency
+	}
+	if opts.HostInterval < 0 {
+		opts.HostInterval = 0
+	}
+	if opts.Retries < 0 {
+		opts.Retries = 0
+	}
+	if opts.Backoff <= 0 {
+		opts.Backoff = DefaultBackoff
+	}
+	return &Checker{opts: opts, next: make(map[string]time.Time)}
+}
+
+// Allowed reports whether u passes the allow and deny patterns.
+func (c *Checker) Allowed(u string) bool {
+	for _, p := range c.opts.Deny {
+		if Match(p, u) {
+			return false
+		}
+	}
+	if len(c.opts.Allow) == 0 {
+		return true
+	}
+	for _, p := range c.opts.Allow {
+		if Match(p, u) {
+			return true
+		}
+	}
+	return false
+}
+
+// Check checks every allowed URL once and returns the results in the order
+// the URLs were first given. Fresh results come from the cache, and new
+// ones are stored in it.
+func (c *Checker) Check(ctx context.Context, urls []string) []Result {
+	var pending []string
+	seen := make(map[string]bool)
+	for _, u := range urls {
+		if !seen[u] && c.Allowed(u) {
+			seen[u] = true
+			pending = append(pending, u)
+		}
+	}
+
+	results := make([]Result, len(pending))
+	jobs := make(chan int)
+	var wg sync.WaitGroup
+	for w := 0; w < c.opts.Concurrency && w < len(pending); w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range jobs {
+				results[i] = c.checkOne(ctx, pending[i])
+			}
+		}()
+	}
+	for i := range pending {
+		jobs <- i
+	}
+	close(jobs)
+	wg.Wait()
+
+	if c.opts.Cache != nil {
+		_ = c.opts.Cache.Save()
+	}
+	return results
+}
+
+func (c *Checker) checkOne(ctx context.Context, u string) Result {
+	if c.opts.Cache != nil {
+		if r, ok := c.opts.Cache.Get(u); ok {
+			return r
+		}
+	}
+
+	backoff := c.opts.Backoff
+	var r Result
+	for attempt := 0; ; attempt++ {
+		var retryAfter time.Duration
+		r, retryAfter = c.request(ctx, u)
+		if !retryable(r) || attempt >= c.opts.Retries {
+			break
+		}
+		wait := backoff
+		if retryAfter > wait {
+			wait = retryAfter
+		}
+		if !sleep(ctx, wait) {
+			break
+		}
+		backoff *= 2
+	}
+
+	if c.opts.Cache != nil && !retryable(r) {
+		c.opts.Cache.Put(r)
+	}
+	return r
+}
+
+// request sends a HEAD request, falling back to GET for servers that do
+// not support HEAD. It also returns the server's Retry-After delay.
+func (c *Checker) request(ctx context.Context, u string) (Result, time.Duration) {
+	parsed, err := url.Parse(u)
+	if err != nil {
+		return Result{URL: u, Err: "invalid URL"}, 0
+	}
+
+	var resp *http.Response
+	for _, method := range []string{http.MethodHead, http.MethodGet} {
+		if !c.wait(ctx, strings.ToLower(parsed.Host)) {
+			return Result{URL: u, Err: ctx.Err().Error()}, 0
+		}
+		reqCtx, cancel := context.WithTimeout(ctx, c.opts.Timeout)
+		req, err := http.NewRequestWithContext(reqCtx, method, u, nil)
+		if err != nil {
+			cancel()
+			return Result{URL: u, Err: "invalid URL"}, 0
+		}
+		req.Header.Set("User-Agent", userAgent)
+		resp, err = c.opts.Client.Do(req)
+		if err != nil {
+			cancel()
+			return Result{URL: u, Err: requestError(err)}, 0
+		}
+		_, _ = io.Copy(io.Discard, io.LimitReader(resp.Body, 64<<10))
+		_ = resp.Body.Close()
+		cancel()
+		if method == http.MethodGet || !headUnsupported(resp.StatusCode) {
+			break
+		}
+	}
+	return Result{URL: u, Status: resp.StatusCode}, retryAfter(resp)
+}
+
+// wait blocks until a request to host may be sent.
+func (c *Checker) wait(ctx context.Context, host string) bool {
+	c.mu.Lock()
+	now := time.Now()
+	at := c.next[host]
+	if at.Before(now) {
+		at = now
+	}
+	c.next[host] = at.Add(c.opts.HostInterval)
+	c.mu.Unlock()
+	return sleep(ctx, time.Until(at))
+}
+
+func sleep(ctx context.Context, d time.Duration) bool {
+	if d <= 0 {
+		return ctx.Err() == nil
+	}
+	t := time.NewTimer(d)
+	defer t.Stop()
+	select {
+	case <-t.C:
+		return true
+	case <-ctx.Done():
+		return false
+	}
+}
+
+// headUnsupported reports whether a HEAD response may just mean the server
+// does not handle HEAD, so GET should be tried.
+func headUnsupported(status int) bool {
+	switch status {
+	case http.StatusBadRequest, http.StatusForbidden, http.StatusNotFound,
+		http.StatusMethodNotAllowed, http.StatusNotImplemented:
+		return true
+	}
+	return false
+}
+
+// retryable reports whether r may succeed on another attempt: network
+// errors, rate limiting and server errors.
+func retryable(r Result) bool {
+	return r.Status == 0 || r.Status == http.StatusTooManyRequests || r.Status >= 500
+}
+
+func retryAfter(resp *http.Response) time.Duration {
+	if resp.StatusCode != http.StatusTooManyRequests && resp.StatusCode != http.StatusServiceUnavailable {
+		return 0
+	}
+	secs, err := strconv.Atoi(strings.TrimSpace(resp.Header.Get("Retry-After")))
+	if err != nil || secs < 0 {
+		return 0
+	}
+	return time.Duration(secs) * time.Second
+}
+
+func requestError(err error) string {
+	if uerr, ok := err.(*url.Error); ok {
+		if uerr.Timeout() {
+			return "timed out"
+		}
+		return uerr.Err.Error()
+	}
+	return err.Error()
+}
+
+// Match reports whether u matches pattern. A pattern containing "://" is
+// matched against the whole URL; any other pattern against the host,
+// ignoring case as hostnames do. In both, * matches any run of characters.
+func Match(pattern, u string) bool {
+	if !strings.Contains(pattern, "://") {
+		parsed, err := url.Parse(u)
+		if err != nil {
+			return false
+		}
+		u = strings.ToLower(parsed.Hostname())
+		pattern = strings.ToLower(pattern)
+	}
+	return wildcardMatch(pattern, u)
+}
+
+func wildcardMatch(pattern, s string) bool {
+	parts := strings.Split(pattern, "*")
+	if len(parts) == 1 {
+		return pattern == s
+	}
+	if !strings.HasPrefix(s, parts[0]) {
+		return false
+	}
+	s = s[len(parts[0]):]
+	for _, part := range parts[1 : len(parts)-1] {
+		i := strings.Index(s, part)
+		if i < 0 {
+			return false
+		}
+		s = s[i+len(part):]
+	}
+	return strings.HasSuffix(s, parts[len(parts)-1])
+}
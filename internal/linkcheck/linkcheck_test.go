@@ -0,0 +1,124 @@
+package linkcheck
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"sync"
+	"testing"
+	"time"
+)
+
+func newServer(t *testing.T) (*httptest.Server, map[string]int) {
+	t.Helper()
+	var mu sync.Mutex
+	hits := make(map[string]int)
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		hits[r.Method+" "+r.URL.Path]++
+		n := hits[r.Method+" "+r.URL.Path]
+		mu.Unlock()
+		switch r.URL.Path {
+		case "/ok":
+		case "/no-head":
+			if r.Method == http.MethodHead {
+				w.WriteHeader(http.StatusMethodNotAllowed)
+			}
+		case "/flaky":
+			if n == 1 {
+				w.Header().Set("Retry-After", "0")
+				w.WriteHeader(http.StatusServiceUnavailable)
+			}
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	t.Cleanup(srv.Close)
+	return srv, hits
+}
+
+func TestCheck(t *testing.T) {
+	srv, hits := newServer(t)
+	c := New(Options{Client: srv.Client(), Retries: 1, Backoff: time.Millisecond, HostInterval: -1})
+
+	urls := []string{srv.URL + "/ok", srv.URL + "/no-head", srv.URL + "/flaky", srv.URL + "/gone", srv.URL + "/ok"}
+	results := c.Check(context.Background(), urls)
+	if len(results) != 4 {
+		t.Fatalf("got %d results, want 4 (duplicates checked once): %+v", len(results), results)
+	}
+	for i, want := range []bool{true, true, true, false} {
+		if results[i].OK() != want {
+			t.Errorf("%s: OK() = %v, want %v (%+v)", results[i].URL, results[i].OK(), want, results[i])
+		}
+	}
+	if got := results[3].Reason(); got != "404 Not Found" {
+		t.Errorf("Reason() = %q", got)
+	}
+	if hits["GET /no-head"] != 1 || hits["HEAD /flaky"] != 2 || hits["HEAD /ok"] != 1 {
+		t.Errorf("hits = %v", hits)
+	}
+
+	closed := httptest.NewServer(http.NotFoundHandler())
+	closed.Close()
+	if r := c.Check(context.Background(), []string{closed.URL}); len(r) != 1 || r[0].OK() || r[0].Err == "" {
+		t.Errorf("unreachable host = %+v, want an error", r)
+	}
+}
+
+func TestHostInterval(t *testing.T) {
+	srv, _ := newServer(t)
+	c := New(Options{Client: srv.Client(), Concurrency: 4, HostInterval: 30 * time.Millisecond})
+	start := time.Now()
+	c.Check(context.Background(), []string{srv.URL + "/ok", srv.URL + "/ok?a", srv.URL + "/ok?b"})
+	if elapsed := time.Since(start); elapsed < 60*time.Millisecond {
+		t.Errorf("three requests to one host took %v, want at least 60ms", elapsed)
+	}
+}
+
+func TestAllowDeny(t *testing.T) {
+	c := New(Options{Allow: []string{"*.example.com", "https://other.org/docs/*"}, Deny: []string{"private.example.com"}})
+	tests := map[string]bool{
+		"https://www.example.com/a":     true,
+		"https://private.example.com/a": false,
+		"https://WWW.Example.COM/a":     true,
+		"https://Private.EXAMPLE.com/a": false,
+		"https://other.org/docs/x":      true,
+		"https://other.org/blog":        false,
+		"https://example.net":           false,
+	}
+	for u, want := range tests {
+		if got := c.Allowed(u); got != want {
+			t.Errorf("Allowed(%q) = %v, want %v", u, got, want)
+		}
+	}
+}
+
+func TestCache(t *testing.T) {
+	srv, hits := newServer(t)
+	path := filepath.Join(t.TempDir(), "links.json")
+	cache, err := LoadCache(path, time.Hour)
+	if err != nil {
+		t.Fatal(err)
+	}
+	c := New(Options{Client: srv.Client(), Cache: cache})
+	c.Check(context.Background(), []string{srv.URL + "/ok", srv.URL + "/gone"})
+
+	reloaded, err := LoadCache(path, time.Hour)
+	if err != nil {
+		t.Fatal(err)
+	}
+	c = New(Options{Client: srv.Client(), Cache: reloaded})
+	results := c.Check(context.Background(), []string{srv.URL + "/ok", srv.URL + "/gone"})
+	if !results[0].Cached || !results[1].Cached || results[1].OK() {
+		t.Errorf("results = %+v, want both cached", results)
+	}
+	if hits["HEAD /ok"] != 1 {
+		t.Errorf("cached URL requested again: %v", hits)
+	}
+
+	reloaded.now = func() time.Time { return time.Now().Add(2 * time.Hour) }
+	if _, ok := reloaded.Get(srv.URL + "/ok"); ok {
+		t.Error("entry older than the TTL should be stale")
+	}
+}
@@ -4,6 +4,7 @@ import (
 	"sort"
 
 	"github.com/mohitmishra786/mdmend/internal/config"
+	"github.com/mohitmishra786/mdmend/internal/markdown"
 	"github.com/mohitmishra786/mdmend/internal/rules"
 )
 
@@ -25,14 +26,23 @@ type LintResult struct {
 	Unfixable  int
 }
 
+// Lint runs the enabled rules on content. CRLF line endings and a leading
+// byte order mark are normalised first so rules only see document text.
+// Violations are only marked fixable when the config allows the rule's fix.
 func (l *Linter) Lint(content string, path string) LintResult {
+	content, _ = markdown.Decode(content)
 	var allViolations []rules.Violation
 	fixable := 0
 	unfixable := 0
 
 	for _, rule := range l.rules {
 		violations := rule.Lint(content, path)
-		for _, v := range violations {
+		canFix := rules.CanFix(l.config, rule)
+		for i, v := range violations {
+			if !canFix {
+				violations[i].Fixable = false
+				v.Fixable = false
+			}
 			if v.Fixable {
 				fixable++
 			} else {
@@ -124,3 +124,12 @@ func TestLintSortByColumn(t *testing.T) {
 		}
 	}
 }
+
+func TestLintCRLFAndBOM(t *testing.T) {
+	l := New(config.Default())
+
+	result := l.Lint("\ufeff# Heading\r\n\r\nText\r\n", "test.md")
+	if len(result.Violations) != 0 {
+		t.Errorf("Lint() = %v, want no violations for CRLF content with a BOM", result.Violations)
+	}
+}
@@ -0,0 +1,80 @@
+package markdown
+
+import (
+	"fmt"
+	"strings"
+)
+
+const (
+	LF   = "\n"
+	CRLF = "\r\n"
+	BOM  = "\ufeff"
+)
+
+// End-of-line settings accepted by ParseEndOfLine.
+const (
+	EndOfLinePreserve = "preserve"
+	EndOfLineLF       = "lf"
+	EndOfLineCRLF     = "crlf"
+)
+
+// Encoding records how a document is stored on disk so that text rules,
+// which work on "\n"-separated lines, can be run on a normalised copy and the
+// result written back in the original form.
+type Encoding struct {
+	BOM        bool
+	LineEnding string
+}
+
+// Decode strips a UTF-8 byte order mark and converts CRLF line endings to
+// LF. The returned Encoding uses the line ending most common in content.
+func Decode(content string) (string, Encoding) {
+	enc := Encoding{LineEnding: LF}
+	if strings.HasPrefix(content, BOM) {
+		enc.BOM = true
+		content = content[len(BOM):]
+	}
+
+	crlf := strings.Count(content, CRLF)
+	if crlf == 0 {
+		return content, enc
+	}
+	if crlf > strings.Count(content, LF)-crlf {
+		enc.LineEnding = CRLF
+	}
+	return strings.ReplaceAll(content, CRLF, LF), enc
+}
+
+// Encode converts LF-separated content back to the recorded line ending and
+// restores the byte order mark.
+func (e Encoding) Encode(content string) string {
+	if e.LineEnding == CRLF {
+		content = strings.ReplaceAll(content, LF, CRLF)
+	}
+	if e.BOM {
+		content = BOM + content
+	}
+	return content
+}
+
+// WithEndOfLine returns e with its line ending replaced according to an
+// end_of_line setting; "" and "preserve" keep the detected one.
+func (e Encoding) WithEndOfLine(setting string) Encoding {
+	switch strings.ToLower(setting) {
+	case EndOfLineLF:
+		e.LineEnding = LF
+	case EndOfLineCRLF:
+		e.LineEnding = CRLF
+	}
+	return e
+}
+
+// ParseEndOfLine validates an end_of_line setting.
+func ParseEndOfLine(setting string) (string, error) {
+	switch s := strings.ToLower(strings.TrimSpace(setting)); s {
+	case "", EndOfLinePreserve, EndOfLineLF, EndOfLineCRLF:
+		return s, nil
+	default:
+		return "", fmt.Errorf("invalid end_of_line %q: use preserve, lf or crlf", setting)
+	}
+}
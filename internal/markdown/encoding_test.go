@@ -0,0 +1,40 @@
+package markdown
+
+import "testing"
+
+func TestDecodeEncode(t *testing.T) {
+	tests := []struct {
+		name   string
+		in     string
+		text   string
+		bom    bool
+		ending string
+	}{
+		{"lf", "a\nb\n", "a\nb\n", false, LF},
+		{"crlf", "a\r\nb\r\n", "a\nb\n", false, CRLF},
+		{"bom", "\ufeff# T\n", "# T\n", true, LF},
+		{"mostly lf", "a\nb\nc\r\n", "a\nb\nc\n", false, LF},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			text, enc := Decode(tt.in)
+			if text != tt.text || enc.BOM != tt.bom || enc.LineEnding != tt.ending {
+				t.Errorf("Decode(%q) = %q, %+v", tt.in, text, enc)
+			}
+			if tt.name != "mostly lf" && enc.Encode(text) != tt.in {
+				t.Errorf("Encode() = %q, want %q", enc.Encode(text), tt.in)
+			}
+		})
+	}
+}
+
+func TestParseEndOfLine(t *testing.T) {
+	for _, ok := range []string{"", "preserve", "LF", "crlf"} {
+		if _, err := ParseEndOfLine(ok); err != nil {
+			t.Errorf("ParseEndOfLine(%q) error = %v", ok, err)
+		}
+	}
+	if _, err := ParseEndOfLine("cr"); err == nil {
+		t.Error("ParseEndOfLine(cr) should fail")
+	}
+}
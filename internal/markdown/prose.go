@@ -0,0 +1,239 @@
+package markdown
+
+import (
+	"regexp"
+	"strings"
+)
+
+var (
+	autolinkRe   = regexp.MustCompile(`<(?:[A-Za-z][A-Za-z0-9+.-]{1,31}:[^\s<>]*|[^\s@<>]+@[^\s@<>]+)>`)
+	htmlTagRe    = regexp.MustCompile(`</?[A-Za-z][A-Za-z0-9-]*(?:\s[^<>]*)?/?>`)
+	linkDestRe   = regexp.MustCompile(`\]\([^)]*\)`)
+	bareURLRe    = regexp.MustCompile(`(?:(?:https?|ftp)://|www\.)[^\s<>()\[\]]+`)
+	refDefRe     = regexp.MustCompile(`^ {0,3}\[[^\]]+\]:`)
+	htmlEntityRe = regexp.MustCompile(`&(?:[A-Za-z][A-Za-z0-9]{1,31}|#[0-9]{1,7}|#[xX][0-9A-Fa-f]{1,6});`)
+)
+
+// ProseLines splits content into lines with everything that is not prose
+// blanked out: front matter, fenced and indented code blocks, HTML comments
+// and tags, inline code, autolinks, bare URLs, link destinations and
+// reference definitions. Blanked bytes become spaces, so byte offsets into a
+// returned line are valid offsets into the original line.
+func ProseLines(content string) []string {
+	return maskLines(content, false, false)
+}
+
+// TextLines is like ProseLines but keeps the contents of code blocks and
+// inline code, masking only the fence lines themselves.
+func TextLines(content string) []string {
+	return maskLines(content, true, false)
+}
+
+// MarkupLines is like ProseLines but keeps HTML tags, URLs, link
+// destinations and reference definitions, blanking only front matter, code
+// and HTML comments.
+func MarkupLines(content string) []string {
+	return maskLines(content, false, true)
+}
+
+func maskLines(content string, keepCode, keepMarkup bool) []string {
+	lines := strings.Split(content, "\n")
+	out := make([]string, len(lines))
+
+	frontMatterEnd := frontMatterEnd(lines)
+	fs := NewFenceState()
+	var indented indentedCode
+	inComment := false
+
+	for i, line := range lines {
+		if i <= frontMatterEnd {
+			out[i] = blank(line)
+			continue
+		}
+
+		if !fs.InFencedBlock() && indented.line(line) {
+			if keepCode {
+				out[i] = line
+			} else {
+				out[i] = blank(line)
+			}
+			continue
+		}
+
+		inFence, isFence := fs.ProcessLine(line, i)
+		if isFence {
+			indented.endParagraph()
+		}
+		if isFence || (inFence && !keepCode) {
+			out[i] = blank(line)
+			continue
+		}
+		if inFence {
+			out[i] = line
+			continue
+		}
+
+		b := []byte(line)
+		inComment = maskComments(b, inComment)
+		if !keepCode {
+			for _, s := range FindInlineCodeSpans(line) {
+				fill(b, s.Start, s.End)
+			}
+		}
+		if keepMarkup {
+			out[i] = string(b)
+			continue
+		}
+		if refDefRe.Match(b) {
+			fill(b, 0, len(b))
+		}
+		for _, re := range []*regexp.Regexp{autolinkRe, htmlTagRe, linkDestRe, bareURLRe, htmlEntityRe} {
+			for _, loc := range re.FindAllIndex(b, -1) {
+				start := loc[0]
+				if re == linkDestRe {
+					start++ // keep the closing bracket of the link text
+				}
+				fill(b, start, loc[1])
+			}
+		}
+		out[i] = string(b)
+	}
+	return out
+}
+
+var (
+	listItemRe      = regexp.MustCompile(`^([-*+]|\d{1,9}[.)])( {1,4}|\t|$)`)
+	atxHeadingRe    = regexp.MustCompile(`^#{1,6}(?:[ \t]|$)`)
+	thematicBreakRe = regexp.MustCompile(`^(?:[-=]+|(?:[-*_][ \t]*){3,})[ \t]*$`)
+)
+
+// indentedCode tracks indented code blocks, including those nested in list
+// items. A block starts after a blank line or another block, never inside a
+// paragraph, on a line indented at least four columns past the content of
+// the enclosing list item, and continues while lines keep that indent.
+type indentedCode struct {
+	items     []int // content columns of the open list items
+	paragraph bool
+	inBlock   bool
+	base      int // content column the open block is indented from
+}
+
+// line reports whether line, which is outside fenced code, belongs to an
+// indented code block.
+func (c *indentedCode) line(line string) bool {
+	rest := strings.TrimLeft(line, " \t")
+	if rest == "" {
+		c.paragraph = false
+		return false
+	}
+	indent := columns(line[:len(line)-len(rest)])
+	if c.inBlock && indent >= c.base+4 {
+		return true
+	}
+	c.inBlock = false
+
+	item := listItemRe.FindStringSubmatch(rest)
+	if !c.paragraph || item != nil {
+		for len(c.items) > 0 && indent < c.items[len(c.items)-1] {
+			c.items = c.items[:len(c.items)-1]
+		}
+	}
+	base := 0
+	if len(c.items) > 0 {
+		base = c.items[len(c.items)-1]
+	}
+	if !c.paragraph && indent >= base+4 {
+		c.inBlock = true
+		c.base = base
+		return true
+	}
+
+	switch {
+	case item != nil:
+		content := columns(line[:len(line)-len(rest)] + item[1] + item[2])
+		if item[2] == "" {
+			content++
+		}
+		c.items = append(c.items, content)
+		c.paragraph = strings.TrimSpace(rest[len(item[0]):]) != ""
+	case atxHeadingRe.MatchString(rest), thematicBreakRe.MatchString(rest):
+		c.paragraph = false
+	default:
+		c.paragraph = true
+	}
+	return false
+}
+
+// endParagraph records a line, such as a code fence, that ends any open
+// paragraph.
+func (c *indentedCode) endParagraph() {
+	c.paragraph = false
+}
+
+// columns returns the width of s with tabs advanced to the next multiple of
+// four.
+func columns(s string) int {
+	n := 0
+	for _, r := range s {
+		if r == '\t' {
+			n += 4 - n%4
+			continue
+		}
+		n++
+	}
+	return n
+}
+
+// frontMatterEnd returns the index of the line closing a YAML (---) or TOML
+// (+++) front matter block at the start of lines, or -1.
+func frontMatterEnd(lines []string) int {
+	if len(lines) == 0 {
+		return -1
+	}
+	delim := strings.TrimRight(lines[0], " \t")
+	if delim != "---" && delim != "+++" {
+		return -1
+	}
+	for i := 1; i < len(lines); i++ {
+		if strings.TrimRight(lines[i], " \t") == delim {
+			return i
+		}
+	}
+	return -1
+}
+
+// maskComments blanks HTML comments in b, which may start or end on other
+// lines, and reports whether a comment is still open at the end of b.
+func maskComments(b []byte, inComment bool) bool {
+	i := 0
+	for i < len(b) {
+		if inComment {
+			end := strings.Index(string(b[i:]), "-->")
+			if end < 0 {
+				fill(b, i, len(b))
+				return true
+			}
+			fill(b, i, i+end+3)
+			i += end + 3
+			inComment = false
+			continue
+		}
+		start := strings.Index(string(b[i:]), "<!--")
+		if start < 0 {
+			return false
+		}
+		i += start
+		inComment = true
+	}
+	return inComment
+}
+
+func fill(b []byte, start, end int) {
+	for i := start; i < end && i < len(b); i++ {
+		b[i] = ' '
+	}
+}
+
+func blank(line string) string {
+	return strings.Repeat(" ", len(line))
+}
@@ -0,0 +1,101 @@
+package markdown
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestProseLines(t *testing.T) {
+	content := strings.Join([]string{
+		"---",
+		"title: github",
+		"---",
+		"See `github` and [github](https://github.com/x) at www.github.com.",
+		"<a href=\"github\">github</a> <!-- github -->",
+		"[ref]: https://github.com",
+		"```",
+		"github",
+		"```",
+	}, "\n")
+
+	lines := ProseLines(content)
+	if len(lines) != 9 {
+		t.Fatalf("got %d lines, want 9", len(lines))
+	}
+	for i, line := range strings.Split(content, "\n") {
+		if len(lines[i]) != len(line) {
+			t.Errorf("line %d: length %d, want %d", i+1, len(lines[i]), len(line))
+		}
+	}
+	if got := strings.Count(lines[3], "github"); got != 1 {
+		t.Errorf("line 4 = %q, want only the link text kept", lines[3])
+	}
+	if got := strings.Fields(lines[4]); len(got) != 1 || got[0] != "github" {
+		t.Errorf("line 5 = %q, want only the element text kept", lines[4])
+	}
+	for _, i := range []int{0, 1, 2, 5, 6, 7, 8} {
+		if strings.TrimSpace(lines[i]) != "" {
+			t.Errorf("line %d = %q, want blank", i+1, lines[i])
+		}
+	}
+
+	text := TextLines(content)
+	if !strings.Contains(text[3], "`github`") || text[7] != "github" {
+		t.Errorf("TextLines should keep code, got %q and %q", text[3], text[7])
+	}
+}
+
+func TestMarkupLines(t *testing.T) {
+	content := "See `[a](x.md)` and [b](y.md) <img src=\"z.png\"> <!-- [c](w.md) -->\n[ref]: ./v.md\n```\n[d](u.md)\n```"
+	lines := MarkupLines(content)
+	want := []string{
+		"See " + strings.Repeat(" ", 11) + " and [b](y.md) <img src=\"z.png\"> " + strings.Repeat(" ", 18),
+		"[ref]: ./v.md",
+		"   ",
+		"         ",
+		"   ",
+	}
+	for i := range want {
+		if lines[i] != want[i] {
+			t.Errorf("line %d = %q, want %q", i+1, lines[i], want[i])
+		}
+	}
+}
+
+func TestProseLinesIndentedCode(t *testing.T) {
+	content := strings.Join([]string{
+		"Intro javascript",
+		"    still javascript prose",
+		"",
+		"    var javascript = 1;",
+		"",
+		"    javascript();",
+		"After javascript",
+		"",
+		"- item javascript",
+		"",
+		"      nested javascript",
+		"  - sub javascript",
+		"",
+		"        deep javascript",
+		"",
+		"  item paragraph javascript",
+		"\tjavascript tab",
+	}, "\n")
+
+	lines := ProseLines(content)
+	code := map[int]bool{3: true, 5: true, 10: true, 13: true}
+	for i, line := range strings.Split(content, "\n") {
+		if line == "" {
+			continue
+		}
+		if got := !strings.Contains(lines[i], "javascript"); got != code[i] {
+			t.Errorf("line %d = %q, want masked %v", i+1, lines[i], code[i])
+		}
+	}
+
+	text := TextLines(content)
+	if text[3] != "    var javascript = 1;" {
+		t.Errorf("TextLines should keep indented code, got %q", text[3])
+	}
+}
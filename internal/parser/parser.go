@@ -18,12 +18,17 @@ func NewSourceFile(path string, content []byte) *SourceFile {
 	}
 }
 
+// splitLines splits on LF, dropping the CR of CRLF line endings.
 func splitLines(content []byte) []string {
 	var lines []string
 	start := 0
 	for i, b := range content {
 		if b == '\n' {
-			lines = append(lines, string(content[start:i]))
+			end := i
+			if end > start && content[end-1] == '\r' {
+				end--
+			}
+			lines = append(lines, string(content[start:end]))
 			start = i + 1
 		}
 	}
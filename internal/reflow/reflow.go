@@ -0,0 +1,375 @@
+// Package reflow rewraps the paragraphs and list items of a Markdown
+// document, either to a line width or to one sentence per line.
+package reflow
+
+import (
+	"regexp"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	"github.com/mohitmishra786/mdmend/internal/markdown"
+)
+
+// Reflow modes.
+const (
+	Wrap     = "wrap"
+	Sentence = "sentence"
+)
+
+// Block is a paragraph or list item body, lines [Start, End) of a document,
+// together with its reflowed lines.
+type Block struct {
+	Start int
+	End   int
+	Lines []string
+}
+
+var (
+	listItemRegex = regexp.MustCompile(`^( *)([-*+]|\d{1,9}[.)])( {1,4})(\[[ xX]\] )?(\S.*)$`)
+	listLikeRegex = regexp.MustCompile(`^ *([-*+]|\d{1,9}[.)])(\s|$)`)
+	atxRegex      = regexp.MustCompile(`^ {0,3}#{1,6}(\s|$)`)
+	fenceRegex    = regexp.MustCompile("^ *(`{3,}|~{3,})")
+	setextRegex   = regexp.MustCompile(`^ {0,3}(=+|-+) *$`)
+	refDefRegex   = regexp.MustCompile(`^ {0,3}\[[^\]]+\]:`)
+	delimiterRow  = regexp.MustCompile(`^ *\|? *:?-+:? *(\| *:?-+:? *)*\|? *$`)
+
+	// atomicRegex matches inline constructs that are never split across
+	// lines: links and images, reference links, autolinks and HTML tags.
+	atomicRegex = regexp.MustCompile(`\[!\[[^\]]*\]\([^)]*\)\]\([^)]*\)|!?\[[^\]]*\]\([^)]*\)|!?\[[^\]]*\]\[[^\]]*\]|<[^<>\n]+>`)
+)
+
+// Blocks returns the paragraphs and list item bodies of lines that
+// reflowing in mode would change. In Wrap mode lines are filled up to width
+// display columns; in Sentence mode every sentence gets its own line and
+// width is ignored. Headings, tables, code, HTML, block quotes, front matter
+// and reference definitions are never touched, and hard line breaks are
+// kept.
+func Blocks(lines []string, mode string, width int) []Block {
+	if mode != Wrap && mode != Sentence {
+		return nil
+	}
+
+	var blocks []Block
+	listIndent := -1
+	i := frontMatterEnd(lines)
+	for i < len(lines) {
+		line := lines[i]
+		trimmed := strings.TrimSpace(line)
+		indent := len(line) - len(strings.TrimLeft(line, " "))
+
+		switch {
+		case trimmed == "":
+			i++
+			continue
+		case fenceRegex.MatchString(line):
+			i = skipFence(lines, i)
+			continue
+		case strings.HasPrefix(trimmed, "<!--"):
+			i = skipUntil(lines, i, func(l string) bool { return strings.Contains(l, "-->") })
+			continue
+		case trimmed == "$$":
+			i = skipUntil(lines, i+1, func(l string) bool { return strings.TrimSpace(l) == "$$" })
+			continue
+		}
+
+		if m := listItemRegex.FindStringSubmatch(line); m != nil && !isThematicBreak(line) &&
+			(indent <= 3 || listIndent >= 0) {
+			end := bodyEnd(lines, i+1)
+			contIndent := len(m[1]) + len(m[2]) + len(m[3])
+			listIndent = contIndent
+			first := m[1] + m[2] + m[3] + m[4]
+			if b, ok := reflowBlock(lines, i, end, first, strings.Repeat(" ", contIndent), mode, width); ok {
+				blocks = append(blocks, b)
+			}
+			i = end
+			continue
+		}
+
+		if listIndent >= 0 && indent < listIndent {
+			listIndent = -1
+		}
+		if atxRegex.MatchString(line) || isThematicBreak(line) {
+			i++
+			continue
+		}
+		if indent >= 4 && (listIndent < 0 || indent >= listIndent+4) || startsBlock(line) ||
+			listLikeRegex.MatchString(line) || line[indent] == '\t' {
+			// Indented code, or a block that is never reflowed.
+			i = skipUntil(lines, i, isBlank)
+			continue
+		}
+
+		end := bodyEnd(lines, i+1)
+		if end < len(lines) && (setextRegex.MatchString(lines[end]) || delimiterRow.MatchString(lines[end])) {
+			// A setext heading or a table.
+			i = skipUntil(lines, end, isBlank)
+			continue
+		}
+		prefix := strings.Repeat(" ", indent)
+		if b, ok := reflowBlock(lines, i, end, prefix, prefix, mode, width); ok {
+			blocks = append(blocks, b)
+		}
+		i = end
+	}
+	return blocks
+}
+
+// bodyEnd returns the index of the first line at or after i that ends the
+// paragraph or list item body containing the line before i.
+func bodyEnd(lines []string, i int) int {
+	for i < len(lines) {
+		line := lines[i]
+		if strings.TrimSpace(line) == "" || startsBlock(line) || listLikeRegex.MatchString(line) ||
+			fenceRegex.MatchString(line) || setextRegex.MatchString(line) || delimiterRow.MatchString(line) {
+			break
+		}
+		i++
+	}
+	return i
+}
+
+// startsBlock reports whether line opens a block that interrupts a
+// paragraph or is never reflowed.
+func startsBlock(line string) bool {
+	trimmed := strings.TrimSpace(line)
+	if atxRegex.MatchString(line) || isThematicBreak(line) || refDefRegex.MatchString(line) ||
+		fenceRegex.MatchString(line) || trimmed == "$$" {
+		return true
+	}
+	for _, p := range []string{">", "|", ":::", "!!!", "???", "[^"} {
+		if strings.HasPrefix(trimmed, p) {
+			return true
+		}
+	}
+	return strings.HasPrefix(trimmed, "<") && !isAutolink(trimmed)
+}
+
+func isThematicBreak(line string) bool {
+	s := strings.ReplaceAll(strings.TrimSpace(line), " ", "")
+	if len(s) < 3 || !strings.ContainsAny(s[:1], "-*_") {
+		return false
+	}
+	return strings.Count(s, s[:1]) == len(s)
+}
+
+var autolinkRegex = regexp.MustCompile(`^<([a-zA-Z][a-zA-Z0-9+.-]{1,31}:[^\s<>]*|[^\s<>@]+@[^\s<>]+)>`)
+
+func isAutolink(s string) bool {
+	return autolinkRegex.MatchString(s)
+}
+
+func frontMatterEnd(lines []string) int {
+	if len(lines) == 0 || (lines[0] != "---" && lines[0] != "+++") {
+		return 0
+	}
+	for i := 1; i < len(lines); i++ {
+		if lines[i] == lines[0] || (lines[0] == "---" && lines[i] == "...") {
+			return i + 1
+		}
+	}
+	return 0
+}
+
+func skipFence(lines []string, i int) int {
+	m := fenceRegex.FindStringSubmatch(lines[i])
+	marker := m[1]
+	for j := i + 1; j < len(lines); j++ {
+		t := strings.TrimSpace(lines[j])
+		if strings.HasPrefix(t, marker) && strings.Trim(t, marker[:1]) == "" {
+			return j + 1
+		}
+	}
+	return len(lines)
+}
+
+func isBlank(line string) bool {
+	return strings.TrimSpace(line) == ""
+}
+
+// skipUntil returns the index after the first line at or after i for which
+// stop returns true.
+func skipUntil(lines []string, i int, stop func(string) bool) int {
+	for ; i < len(lines); i++ {
+		if stop(lines[i]) {
+			return i + 1
+		}
+	}
+	return len(lines)
+}
+
+// segment is a run of words ending in a hard line break or the end of a
+// block. trailing is the whitespace of a two-space hard break.
+type segment struct {
+	words    []string
+	trailing string
+}
+
+// reflowBlock reflows lines[start:end], writing first before the first line
+// and rest before every other line. It reports false when nothing changes.
+func reflowBlock(lines []string, start, end int, first, rest, mode string, width int) (Block, bool) {
+	var segments []segment
+	var text []string
+	for i := start; i < end; i++ {
+		line := lines[i]
+		if i == start {
+			line = line[len(first):]
+		}
+		content := strings.TrimLeft(line, " \t")
+		trimmed := strings.TrimRight(content, " \t")
+		text = append(text, trimmed)
+
+		spaces := i < end-1 && strings.HasSuffix(content, "  ")
+		backslash := i < end-1 && strings.HasSuffix(trimmed, "\\") && !strings.HasSuffix(trimmed, "\\\\")
+		if spaces || backslash || i == end-1 {
+			seg := segment{words: tokenize(strings.Join(text, " "))}
+			if spaces {
+				seg.trailing = content[len(trimmed):]
+			}
+			segments = append(segments, seg)
+			text = nil
+		}
+	}
+
+	var out []string
+	prefix := func() string {
+		if len(out) == 0 {
+			return first
+		}
+		return rest
+	}
+	for _, seg := range segments {
+		if len(seg.words) == 0 {
+			continue
+		}
+		if mode == Sentence {
+			for n, sentence := range splitSentences(seg.words) {
+				joined := strings.Join(sentence, " ")
+				if n > 0 && !safeLineStart(sentence[0]) {
+					out[len(out)-1] += " " + joined
+					continue
+				}
+				out = append(out, prefix()+joined)
+			}
+		} else {
+			cur := ""
+			for _, w := range seg.words {
+				switch {
+				case cur == "":
+					cur = w
+				case width > 0 && markdown.DisplayWidth(prefix()+cur+" "+w) > width && safeLineStart(w):
+					out = append(out, prefix()+cur)
+					cur = w
+				default:
+					cur += " " + w
+				}
+			}
+			out = append(out, prefix()+cur)
+		}
+		out[len(out)-1] += seg.trailing
+	}
+
+	if len(out) == end-start {
+		same := true
+		for i, l := range out {
+			if lines[start+i] != l {
+				same = false
+				break
+			}
+		}
+		if same {
+			return Block{}, false
+		}
+	}
+	return Block{Start: start, End: end, Lines: out}, true
+}
+
+// tokenize splits text into words at whitespace outside inline code, links,
+// autolinks and HTML tags.
+func tokenize(text string) []string {
+	var spans []markdown.Span
+	spans = append(spans, markdown.FindInlineCodeSpans(text)...)
+	for _, m := range atomicRegex.FindAllStringIndex(text, -1) {
+		if !markdown.OverlapsSpan(m[0], m[1], spans) {
+			spans = append(spans, markdown.Span{Start: m[0], End: m[1]})
+		}
+	}
+
+	var words []string
+	start := -1
+	for i := 0; i < len(text); i++ {
+		space := (text[i] == ' ' || text[i] == '\t') && !markdown.IsInsideSpan(i, spans)
+		switch {
+		case space && start >= 0:
+			words = append(words, text[start:i])
+			start = -1
+		case !space && start < 0:
+			start = i
+		}
+	}
+	if start >= 0 {
+		words = append(words, text[start:])
+	}
+	return words
+}
+
+var (
+	orderedMarker = regexp.MustCompile(`^\d{1,9}[.)]$`)
+	headingMarker = regexp.MustCompile(`^#{1,6}$`)
+	ruleMarker    = regexp.MustCompile(`^[-=_*+]+$`)
+)
+
+// safeLineStart reports whether a line may start with w without becoming a
+// list item, heading, block quote, code fence, HTML block or other block.
+func safeLineStart(w string) bool {
+	if orderedMarker.MatchString(w) || headingMarker.MatchString(w) || ruleMarker.MatchString(w) {
+		return false
+	}
+	for _, p := range []string{">", "|", "```", "~~~", "$$", ":::", "!!!", "???"} {
+		if strings.HasPrefix(w, p) {
+			return false
+		}
+	}
+	if strings.HasPrefix(w, "[") && strings.HasSuffix(w, "]:") {
+		return false
+	}
+	return !strings.HasPrefix(w, "<") || isAutolink(w)
+}
+
+var abbreviations = map[string]bool{
+	"e.g.": true, "i.e.": true, "cf.": true, "vs.": true,
+	"mr.": true, "mrs.": true, "ms.": true, "dr.": true, "prof.": true,
+	"sr.": true, "jr.": true, "st.": true, "no.": true, "fig.": true,
+	"approx.": true, "inc.": true, "ltd.": true, "co.": true,
+}
+
+// splitSentences groups words into sentences. A sentence ends with ., ! or
+// ? when the next word starts with a capital letter or a digit, except
+// after common abbreviations and initials.
+func splitSentences(words []string) [][]string {
+	var sentences [][]string
+	start := 0
+	for i := 0; i+1 < len(words); i++ {
+		if endsSentence(words[i], words[i+1]) {
+			sentences = append(sentences, words[start:i+1])
+			start = i + 1
+		}
+	}
+	return append(sentences, words[start:])
+}
+
+func endsSentence(w, next string) bool {
+	core := strings.TrimRight(w, "\"')]*_”’")
+	if core == "" || !strings.ContainsAny(core[len(core)-1:], ".!?") {
+		return false
+	}
+	if abbreviations[strings.ToLower(core)] {
+		return false
+	}
+	stem := strings.TrimRight(core, ".!?")
+	if r, size := utf8.DecodeRuneInString(stem); size == len(stem) && unicode.IsUpper(r) {
+		return false // an initial, as in "J. R. R. Tolkien"
+	}
+	r, _ := utf8.DecodeRuneInString(strings.TrimLeft(next, "\"'(*_[“‘"))
+	return unicode.IsUpper(r) || unicode.IsDigit(r)
+}
@@ -0,0 +1,97 @@
+package reflow
+
+import (
+	"strings"
+	"testing"
+)
+
+func apply(doc, mode string, width int) string {
+	lines := strings.Split(doc, "\n")
+	blocks := Blocks(lines, mode, width)
+	for i := len(blocks) - 1; i >= 0; i-- {
+		b := blocks[i]
+		lines = append(append(append([]string(nil), lines[:b.Start]...), b.Lines...), lines[b.End:]...)
+	}
+	return strings.Join(lines, "\n")
+}
+
+func TestWrap(t *testing.T) {
+	tests := []struct {
+		name string
+		in   string
+		want string
+	}{
+		{
+			"paragraph",
+			"One two three four five six seven eight nine ten.\nEleven.",
+			"One two three four five six\nseven eight nine ten. Eleven.",
+		},
+		{
+			"atomic spans",
+			"See `a b c d` and [the link text](https://example.com) now.",
+			"See `a b c d` and\n[the link text](https://example.com)\nnow.",
+		},
+		{
+			"list item",
+			"- [ ] one two three four five six seven eight\n  nine\n  1. ten eleven twelve thirteen fourteen",
+			"- [ ] one two three four five\n  six seven eight nine\n  1. ten eleven twelve thirteen\n     fourteen",
+		},
+		{
+			"hard breaks",
+			"one two three four five six seven  \neight\\\nnine ten eleven twelve thirteen fourteen",
+			"one two three four five six\nseven  \neight\\\nnine ten eleven twelve thirteen\nfourteen",
+		},
+		{
+			"no block syntax at line start",
+			"aaaa bbbb cccc dddd eeee ffff gg - hh\n\naaaa bbbb cccc dddd eeee ffff gg 1. hh\n\naaaa bbbb cccc dddd eeee ffff gg # hh",
+			"aaaa bbbb cccc dddd eeee ffff gg -\nhh\n\naaaa bbbb cccc dddd eeee ffff gg 1.\nhh\n\naaaa bbbb cccc dddd eeee ffff gg #\nhh",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := apply(tt.in, Wrap, 32)
+			if got != tt.want {
+				t.Errorf("got:\n%s\nwant:\n%s", got, tt.want)
+			}
+			if again := Blocks(strings.Split(got, "\n"), Wrap, 32); len(again) != 0 {
+				t.Errorf("not idempotent: %+v", again)
+			}
+		})
+	}
+}
+
+func TestWrapSkipsBlocks(t *testing.T) {
+	long := strings.Repeat("word ", 12)
+	doc := strings.Join([]string{
+		"---", "title: " + long, "---",
+		"# " + long,
+		"",
+		long, "===",
+		"",
+		"> " + long,
+		"",
+		"```", long, "```",
+		"",
+		"    " + long,
+		"",
+		"| " + long + " |", "| --- |",
+		"",
+		"<div>", long, "</div>",
+		"",
+		"[ref]: https://example.com " + long,
+	}, "\n")
+	if blocks := Blocks(strings.Split(doc, "\n"), Wrap, 20); len(blocks) != 0 {
+		t.Errorf("Blocks() = %+v, want none", blocks)
+	}
+}
+
+func TestSentence(t *testing.T) {
+	in := "First sentence, e.g. this one. Second! Is it\nthird? Dr. Who met J. R. R. Tolkien in 2024. 2025 was next."
+	want := "First sentence, e.g. this one.\nSecond!\nIs it third?\nDr. Who met J. R. R. Tolkien in 2024.\n2025 was next."
+	if got := apply(in, Sentence, 10); got != want {
+		t.Errorf("got:\n%s\nwant:\n%s", got, want)
+	}
+	if got := apply("- One. Two.\n\n  Three. Four.", Sentence, 0); got != "- One.\n  Two.\n\n  Three.\n  Four." {
+		t.Errorf("list sentences = %q", got)
+	}
+}
@@ -32,13 +32,15 @@ type JSONViolation struct {
 	Message   string `json:"message"`
 	Fixable   bool   `json:"fixable"`
 	Suggested string `json:"suggested,omitempty"`
+	Severity  string `json:"severity,omitempty"`
 }
 
 type JSONFileResult struct {
-	Path       string          `json:"path"`
-	Violations []JSONViolation `json:"violations"`
-	Fixed      int             `json:"fixed,omitempty"`
-	Error      string          `json:"error,omitempty"`
+	Path        string          `json:"path"`
+	Violations  []JSONViolation `json:"violations"`
+	Fixed       int             `json:"fixed,omitempty"`
+	WouldChange bool            `json:"would_change,omitempty"`
+	Error       string          `json:"error,omitempty"`
 }
 
 type JSONOutput struct {
@@ -53,6 +55,8 @@ type JSONSummary struct {
 	TotalViolations int `json:"total_violations"`
 	Fixable         int `json:"fixable"`
 	Unfixable       int `json:"unfixable"`
+	FilesToChange   int `json:"files_to_change,omitempty"`
+	Errors          int `json:"errors,omitempty"`
 }
 
 func (r *JSONReporter) Report(path string, violations []rules.Violation) error {
@@ -85,6 +89,7 @@ func ConvertViolations(violations []rules.Violation) []JSONViolation {
 			Message:   v.Message,
 			Fixable:   v.Fixable,
 			Suggested: v.Suggested,
+			Severity:  string(v.Severity),
 		}
 	}
 	return result
@@ -125,6 +125,21 @@ func TestJSONReporterOutputResults(t *testing.T) {
 	}
 }
 
+func TestJSONReporterOutputResultsWouldChange(t *testing.T) {
+	var buf bytes.Buffer
+	jr := NewJSONReporterWithWriter(&buf)
+
+	results := []JSONFileResult{{Path: "test.md", Violations: []JSONViolation{}, Fixed: 2, WouldChange: true}}
+	if err := jr.OutputResults(results, JSONSummary{TotalFiles: 3, FilesToChange: 1}); err != nil {
+		t.Fatalf("OutputResults() error = %v", err)
+	}
+
+	out := buf.String()
+	if !strings.Contains(out, `"would_change": true`) || !strings.Contains(out, `"files_to_change": 1`) {
+		t.Errorf("OutputResults() = %s, want would_change and files_to_change", out)
+	}
+}
+
 func TestConvertViolations(t *testing.T) {
 	violations := []rules.Violation{
 		{Rule: "MD010", Line: 1, Column: 1, Message: "Hard tab", Fixable: true, Suggested: "fix"},
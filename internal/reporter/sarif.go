@@ -108,6 +108,12 @@ func (r *SARIFReporter) OutputResults(results []JSONFileResult, summary JSONSumm
 			if !v.Fixable {
 				level = "error"
 			}
+			switch v.Severity {
+			case "error", "warning":
+				level = v.Severity
+			case "info":
+				level = "note"
+			}
 			message := v.Message
 			if message == "" {
 				message = fmt.Sprintf("%s violation", v.Rule)
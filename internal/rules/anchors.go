@@ -0,0 +1,84 @@
+package rules
+
+import (
+	"regexp"
+	"strings"
+	"sync"
+
+	"github.com/mohitmishra786/mdmend/internal/markdown"
+	"github.com/mohitmishra786/mdmend/internal/slug"
+)
+
+// headingAnchor is a heading and the anchor the slug style generates for
+// it. Text excludes any explicit {#id} attribute.
+type headingAnchor struct {
+	line   int
+	level  int
+	text   string
+	anchor string
+}
+
+var htmlAnchorAttrRegex = regexp.MustCompile(`\s(?i:(id|name))\s*=\s*(?:"([^"]*)"|'([^']*)'|([^\s"'>]+))`)
+
+// collectHeadingAnchors returns the headings outside code, comments and
+// front matter in document order. Anchors are numbered across the whole
+// document, as platforms do, so a repeated heading gets the style's suffix.
+func collectHeadingAnchors(content, style string) []headingAnchor {
+	lines := strings.Split(content, "\n")
+	masked := markdown.MarkupLines(content)
+	slugger := slug.New(style)
+
+	var headings []headingAnchor
+	for i, line := range lines {
+		if strings.TrimSpace(masked[i]) == "" {
+			continue
+		}
+		text, level := extractHeading(line, masked, i)
+		if level == 0 {
+			continue
+		}
+		anchor, rest := slugger.Heading(text)
+		headings = append(headings, headingAnchor{line: i + 1, level: level, text: strings.TrimSpace(rest), anchor: anchor})
+	}
+	return headings
+}
+
+// collectAnchors returns every fragment a link into content can target:
+// heading anchors under the slug style and HTML id and name attributes.
+func collectAnchors(content, style string) map[string]bool {
+	anchors := make(map[string]bool)
+	for _, h := range collectHeadingAnchors(content, style) {
+		if h.anchor != "" {
+			anchors[h.anchor] = true
+		}
+	}
+	for _, line := range markdown.MarkupLines(content) {
+		for _, tag := range brokenLinkTagRegex.FindAllString(line, -1) {
+			for _, m := range htmlAnchorAttrRegex.FindAllStringSubmatch(tag, -1) {
+				if id := m[2] + m[3] + m[4]; id != "" {
+					anchors[id] = true
+				}
+			}
+		}
+	}
+	return anchors
+}
+
+// anchorIndex holds a file's content and its anchors under each slug style
+// asked for, so a link target is parsed once per run and style.
+type anchorIndex struct {
+	content string
+	mu      sync.Mutex
+	styles  map[string]map[string]bool
+}
+
+func (x *anchorIndex) anchors(style string) map[string]bool {
+	x.mu.Lock()
+	defer x.mu.Unlock()
+	if a, ok := x.styles[style]; ok {
+		return a
+	}
+	a := collectAnchors(x.content, style)
+	x.styles[style] = a
+	return a
+}
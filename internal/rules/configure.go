@@ -7,6 +7,8 @@ func ConfigureFromConfig(r Rule, cfg *config.Config) Rule {
 		return r
 	}
 	rc := cfg.GetRuleConfig(r.ID())
+	enabled := cfg.IsEnabled(r.ID())
+	rc.Enabled = &enabled
 	return Configure(r, rc, cfg)
 }
 
@@ -52,6 +54,9 @@ func Configure(r Rule, rc config.RuleConfig, cfg *config.Config) Rule {
 		if rc.Tables != nil {
 			clone.Tables = *rc.Tables
 		}
+		if rc.Reflow != "" {
+			clone.Reflow = rc.Reflow
+		}
 		return &clone
 	case *MD014:
 		clone := *rule
@@ -166,6 +171,9 @@ func Configure(r Rule, rc config.RuleConfig, cfg *config.Config) Rule {
 		if len(rc.Names) > 0 {
 			clone.Names = append([]string(nil), rc.Names...)
 		}
+		if rc.CodeBlocks != nil {
+			clone.CodeBlocks = *rc.CodeBlocks
+		}
 		return &clone
 	case *MD045:
 		clone := *rule
@@ -204,6 +212,7 @@ func Configure(r Rule, rc config.RuleConfig, cfg *config.Config) Rule {
 		}
 		if cfg != nil {
 			clone.Aggressive = cfg.Aggressive
+			clone.SlugStyle = cfg.SlugStyle
 		}
 		return &clone
 	case *MD054:
@@ -223,6 +232,24 @@ func Configure(r Rule, rc config.RuleConfig, cfg *config.Config) Rule {
 		if rc.SuggestClosest != nil {
 			clone.SuggestClosest = *rc.SuggestClosest
 		}
+		if cfg != nil {
+			clone.SlugStyle = cfg.SlugStyle
+		}
+		return &clone
+	case *MD059:
+		clone := *rule
+		if len(rc.ProhibitedTexts) > 0 {
+			clone.ProhibitedTexts = append([]string(nil), rc.ProhibitedTexts...)
+		}
+		if len(rc.Languages) > 0 {
+			clone.Languages = append([]string(nil), rc.Languages...)
+		}
+		return &clone
+	case *MD060:
+		clone := *rule
+		if rc.Style != "" {
+			clone.Style = rc.Style
+		}
 		return &clone
 	case *MD070:
 		clone := *rule
@@ -238,6 +265,66 @@ func Configure(r Rule, rc config.RuleConfig, cfg *config.Config) Rule {
 		if rc.Level > 0 {
 			clone.MinLevel = rc.Level
 		}
+		if rc.MaxLevel > 0 {
+			clone.MaxLevel = rc.MaxLevel
+		}
+		if rc.Insert != nil {
+			clone.Insert = *rc.Insert
+		}
+		if cfg != nil {
+			clone.SlugStyle = cfg.SlugStyle
+			// Match the list rules unless the TOC sets its own style.
+			if style := cfg.GetRuleConfig("MD004").Style; rc.Style == "" && (style == "dash" || style == "asterisk" || style == "plus") {
+				clone.Style = style
+			}
+			if indent := cfg.GetRuleConfig("MD007").Indent; rc.Indent == 0 && indent > 0 {
+				clone.Indent = indent
+			}
+		}
+		if rc.Style != "" {
+			clone.Style = rc.Style
+		}
+		if rc.Indent > 0 {
+			clone.Indent = rc.Indent
+		}
+		clone.CheckFormat = rc.Style != "" || rc.Indent > 0
+		return &clone
+	case *MD074:
+		clone := *rule
+		if rc.Vocabulary != "" {
+			clone.Vocabulary = rc.Vocabulary
+		}
+		return &clone
+	case *MD075:
+		clone := *rule
+		if len(rc.Dictionaries) > 0 {
+			clone.Dictionaries = append([]string(nil), rc.Dictionaries...)
+		}
+		if len(rc.Words) > 0 {
+			clone.Words = append([]string(nil), rc.Words...)
+		}
+		return &clone
+	case *MD076:
+		clone := *rule
+		if rc.Style != "" {
+			clone.Style = rc.Style
+		}
+		if len(rc.SmallWords) > 0 {
+			clone.SmallWords = append([]string(nil), rc.SmallWords...)
+		}
+		clone.Names = append([]string(nil), rc.Names...)
+		if cfg != nil {
+			clone.Names = append(clone.Names, cfg.GetRuleConfig("MD044").Names...)
+		}
+		return &clone
+	case *MD077:
+		clone := *rule
+		if rc.Enabled != nil {
+			clone.Enabled = *rc.Enabled
+		}
+		if clone.Enabled && clone.Checker == nil {
+			clone.Checker, clone.OptionsErr = externalChecker(rc)
+		}
 		return &clone
 	default:
 		return r
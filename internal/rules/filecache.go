@@ -0,0 +1,43 @@
+package rules
+
+import (
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+)
+
+// fileCache keeps values parsed from files until the file changes, since
+// rules are configured afresh for every file they check.
+type fileCache struct {
+	entries sync.Map
+}
+
+type fileCacheEntry struct {
+	modTime time.Time
+	size    int64
+	value   interface{}
+}
+
+func (c *fileCache) load(path string, parse func(string) (interface{}, error)) (interface{}, error) {
+	abs, err := filepath.Abs(path)
+	if err != nil {
+		return nil, err
+	}
+	info, err := os.Stat(abs)
+	if err != nil {
+		return nil, err
+	}
+	if e, ok := c.entries.Load(abs); ok {
+		cached := e.(fileCacheEntry)
+		if cached.modTime.Equal(info.ModTime()) && cached.size == info.Size() {
+			return cached.value, nil
+		}
+	}
+	v, err := parse(abs)
+	if err != nil {
+		return nil, err
+	}
+	c.entries.Store(abs, fileCacheEntry{modTime: info.ModTime(), size: info.Size(), value: v})
+	return v, nil
+}
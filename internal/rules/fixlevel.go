@@ -0,0 +1,57 @@
+package rules
+
+import "github.com/mohitmishra786/mdmend/internal/config"
+
+// safeFixes lists the rules whose fixes only change whitespace or markup
+// structure, never the text a reader sees. Every other fixable rule,
+// including custom rules, is treated as unsafe.
+var safeFixes = map[string]bool{
+	"MD003": true,
+	"MD004": true,
+	"MD005": true,
+	"MD007": true,
+	"MD009": true,
+	"MD010": true,
+	"MD012": true,
+	"MD018": true,
+	"MD019": true,
+	"MD020": true,
+	"MD021": true,
+	"MD022": true,
+	"MD023": true,
+	"MD027": true,
+	"MD028": true,
+	"MD030": true,
+	"MD031": true,
+	"MD032": true,
+	"MD035": true,
+	"MD037": true,
+	"MD038": true,
+	"MD039": true,
+	"MD047": true,
+	"MD048": true,
+	"MD049": true,
+	"MD050": true,
+	"MD055": true,
+	"MD058": true,
+	"MD060": true,
+	"MD070": true,
+}
+
+// FixLevel returns config.FixLevelSafe or config.FixLevelUnsafe for a rule's
+// fixes.
+func FixLevel(id string) string {
+	if safeFixes[id] {
+		return config.FixLevelSafe
+	}
+	return config.FixLevelUnsafe
+}
+
+// CanFix reports whether cfg allows r's fixes to be applied: r must be
+// fixable, not set to fix: false, and within the configured fix level.
+func CanFix(cfg *config.Config, r Rule) bool {
+	if !r.Fixable() || !cfg.FixEnabled(r.ID()) {
+		return false
+	}
+	return cfg.GetFixLevel() == config.FixLevelUnsafe || FixLevel(r.ID()) == config.FixLevelSafe
+}
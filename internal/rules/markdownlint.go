@@ -0,0 +1,118 @@
+package rules
+
+import (
+	"sort"
+
+	"github.com/mohitmishra786/mdmend/internal/config"
+)
+
+type Compatibility string
+
+const (
+	CompatSame             Compatibility = "same"
+	CompatDifferent        Compatibility = "different"
+	CompatMdmendOnly       Compatibility = "mdmend-only"
+	CompatMarkdownlintOnly Compatibility = "markdownlint-only"
+)
+
+type MarkdownlintInfo struct {
+	Name   string
+	Compat Compatibility
+	Note   string
+}
+
+var markdownlintCompat = map[string]MarkdownlintInfo{
+	"MD009": {Name: "no-trailing-spaces", Compat: CompatDifferent, Note: "all trailing spaces are reported; markdownlint allows br_spaces hard breaks"},
+	"MD010": {Name: "no-hard-tabs", Compat: CompatDifferent, Note: "tabs inside code blocks are reported and replaced; code_blocks is not supported"},
+	"MD013": {Name: "line-length", Compat: CompatDifferent, Note: "length is measured in bytes and lines starting with a URL are skipped; reflow adds an mdmend-only fix that rewraps paragraphs"},
+	"MD014": {Name: "commands-show-output", Compat: CompatDifferent, Note: "smart mode skips blocks that mix commands and output; fix strips the $ prompt"},
+	"MD024": {Name: "no-duplicate-heading", Compat: CompatDifferent, Note: "allow_different_nesting approximates siblings_only"},
+	"MD028": {Name: "no-blanks-blockquote", Compat: CompatDifferent, Note: "auto-fixable in mdmend; markdownlint only reports"},
+	"MD034": {Name: "no-bare-urls", Compat: CompatDifferent, Note: "heuristic fix wraps URLs as <url> or [url](url); skip_patterns is mdmend-only"},
+	"MD040": {Name: "fenced-code-language", Compat: CompatDifferent, Note: "fix infers the fence language and falls back to a configurable tag"},
+	"MD041": {Name: "first-line-heading", Compat: CompatDifferent, Note: "fix can promote the first line or derive a title from the filename"},
+	"MD044": {Name: "proper-names", Compat: CompatDifferent, Note: "names are matched as whole words in prose; code_blocks: true reports, but never fixes, names in code; URLs, link targets and HTML are never checked"},
+	"MD049": {Name: "emphasis-style", Compat: CompatDifferent, Note: "consistent style is not supported; defaults to asterisk"},
+	"MD050": {Name: "strong-style", Compat: CompatDifferent, Note: "consistent style is not supported; defaults to asterisk"},
+	"MD051": {Name: "link-fragments", Compat: CompatDifferent, Note: "slugs follow slug_style (github, gitlab, mkdocs, docusaurus, hugo or pandoc) and {#id} attributes; --aggressive fixes near-miss fragments"},
+	"MD053": {Name: "link-image-reference-definitions", Compat: CompatDifferent, Note: "fix removes unused reference definitions"},
+	"MD054": {Name: "link-image-style", Compat: CompatDifferent, Note: "configured with a single preferred style instead of per-style switches"},
+	"MD056": {Name: "table-column-count", Compat: CompatDifferent, Note: "fix pads short rows when pad_short_rows is enabled"},
+	"MD059": {Name: "descriptive-link-text", Compat: CompatDifferent, Note: "the English pack also bans phrases such as \"read more\" and languages adds other packs; URLs as link text and one text linking to different targets are also reported"},
+	"MD060": {Name: "table-column-style", Compat: CompatDifferent, Note: "fix reformats whole tables; explicit styles also normalise delimiter cells to ---, :---, ---: or :---:; widths count East Asian wide characters as two columns"},
+
+	"MD057": {Compat: CompatMdmendOnly, Note: "relative links, images, reference definitions and HTML href/src must exist; fragments must match a heading in the target file"},
+	"MD066": {Compat: CompatMdmendOnly, Note: "footnote references must have definitions"},
+	"MD067": {Compat: CompatMdmendOnly, Note: "footnote definitions should follow reference order"},
+	"MD068": {Compat: CompatMdmendOnly, Note: "footnote definitions must not be empty"},
+	"MD070": {Compat: CompatMdmendOnly, Note: "nested Markdown fence length"},
+	"MD073": {Compat: CompatMdmendOnly, Note: "table of contents validation and generation"},
+	"MD074": {Compat: CompatMdmendOnly, Note: "terminology from a vocabulary file"},
+	"MD075": {Compat: CompatMdmendOnly, Note: "offline spell checking of prose"},
+	"MD076": {Compat: CompatMdmendOnly, Note: "heading capitalisation"},
+	"MD077": {Compat: CompatMdmendOnly, Note: "external links are requested over the network; off by default"},
+}
+
+func MarkdownlintCompat(id string) MarkdownlintInfo {
+	if info, ok := markdownlintCompat[id]; ok {
+		return info
+	}
+	if r := Get(id); r != nil {
+		if config.IsMarkdownlintRule(id) {
+			return MarkdownlintInfo{Name: r.Name(), Compat: CompatSame}
+		}
+		return MarkdownlintInfo{Compat: CompatMdmendOnly}
+	}
+	if config.IsMarkdownlintRule(id) {
+		return MarkdownlintInfo{Compat: CompatMarkdownlintOnly, Note: "not implemented by mdmend"}
+	}
+	return MarkdownlintInfo{}
+}
+
+type MigrationNote struct {
+	Rule    string
+	Name    string
+	Compat  Compatibility
+	Enabled bool
+	Note    string
+}
+
+// MigrationReport lists every rule whose behavior differs between mdmend and
+// markdownlint, along with whether cfg enables it in mdmend.
+func MigrationReport(cfg *config.Config) []MigrationNote {
+	ids := map[string]struct{}{}
+	for _, id := range IDs() {
+		ids[id] = struct{}{}
+	}
+	for _, id := range config.MarkdownlintRuleIDs() {
+		ids[id] = struct{}{}
+	}
+
+	var notes []MigrationNote
+	for id := range ids {
+		info := MarkdownlintCompat(id)
+		if info.Compat == CompatSame || info.Compat == "" {
+			continue
+		}
+		name := info.Name
+		if r := Get(id); r != nil && name == "" {
+			name = r.Name()
+		}
+		enabled := false
+		if r := Get(id); r != nil && cfg != nil {
+			enabled = cfg.IsEnabled(id)
+		}
+		notes = append(notes, MigrationNote{
+			Rule:    id,
+			Name:    name,
+			Compat:  info.Compat,
+			Enabled: enabled,
+			Note:    info.Note,
+		})
+	}
+
+	sort.Slice(notes, func(i, j int) bool {
+		return notes[i].Rule < notes[j].Rule
+	})
+	return notes
+}
@@ -2,13 +2,18 @@ package rules
 
 import (
 	"strings"
+
+	"github.com/mohitmishra786/mdmend/internal/reflow"
 )
 
+// MD013 reports long lines. When Reflow is "wrap" or "sentence" it also
+// fixes them by rewrapping paragraphs and list items.
 type MD013 struct {
 	LineLength int
 	CodeBlocks bool
 	Tables     bool
 	Enabled    bool
+	Reflow     string
 }
 
 func init() {
@@ -18,7 +23,9 @@ func init() {
 func (r *MD013) ID() string          { return "MD013" }
 func (r *MD013) Name() string        { return "line-length" }
 func (r *MD013) Description() string { return "Line length should not exceed configured limit" }
-func (r *MD013) Fixable() bool       { return false }
+func (r *MD013) Fixable() bool {
+	return r.Reflow == reflow.Wrap || r.Reflow == reflow.Sentence
+}
 
 func (r *MD013) Lint(content string, path string) []Violation {
 	if !r.Enabled {
@@ -32,11 +39,30 @@ func (r *MD013) Lint(content string, path string) []Violation {
 		limit = 120
 	}
 
+	blocks := r.reflowBlocks(lines, limit)
+	blockAt := make(map[int]int)
+	for n, b := range blocks {
+		for i := b.Start; i < b.End; i++ {
+			blockAt[i] = n
+		}
+	}
+
 	inCodeBlock := false
 	inTable := false
 
 	for i, line := range lines {
 		trimmed := strings.TrimSpace(line)
+		n, inBlock := blockAt[i]
+
+		if r.Reflow == reflow.Sentence && inBlock && blocks[n].Start == i {
+			violations = append(violations, Violation{
+				Rule:    r.ID(),
+				Line:    i + 1,
+				Column:  1,
+				Message: "Paragraph should have one sentence per line",
+				Fixable: true,
+			})
+		}
 
 		if strings.HasPrefix(trimmed, "```") || strings.HasPrefix(trimmed, "~~~") {
 			inCodeBlock = !inCodeBlock
@@ -67,7 +93,7 @@ func (r *MD013) Lint(content string, path string) []Violation {
 				Line:      i + 1,
 				Column:    limit + 1,
 				Message:   "Line exceeds configured length limit",
-				Fixable:   false,
+				Fixable:   inBlock,
 				Suggested: "",
 			})
 		}
@@ -78,7 +104,40 @@ func (r *MD013) Lint(content string, path string) []Violation {
 
 func (r *MD013) Fix(content string, path string) FixResult {
 	lines := strings.Split(content, "\n")
-	return FixResult{Changed: false, Lines: lines}
+	if !r.Enabled {
+		return FixResult{Changed: false, Lines: lines}
+	}
+	limit := r.LineLength
+	if limit <= 0 {
+		limit = 120
+	}
+
+	blocks := r.reflowBlocks(lines, limit)
+	for n := len(blocks) - 1; n >= 0; n-- {
+		b := blocks[n]
+		lines = append(lines[:b.Start], append(b.Lines, lines[b.End:]...)...)
+	}
+	return FixResult{Changed: len(blocks) > 0, Lines: lines}
+}
+
+// reflowBlocks returns the blocks the fix rewrites: in wrap mode the
+// paragraphs with a line over limit, in sentence mode every paragraph that
+// is not already one sentence per line.
+func (r *MD013) reflowBlocks(lines []string, limit int) []reflow.Block {
+	blocks := reflow.Blocks(lines, r.Reflow, limit)
+	if r.Reflow != reflow.Wrap {
+		return blocks
+	}
+	var long []reflow.Block
+	for _, b := range blocks {
+		for i := b.Start; i < b.End; i++ {
+			if len(lines[i]) > limit {
+				long = append(long, b)
+				break
+			}
+		}
+	}
+	return long
 }
 
 func isMD013TableLine(line string) bool {
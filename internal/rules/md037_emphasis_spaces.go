@@ -3,6 +3,8 @@ package rules
 import (
 	"regexp"
 	"strings"
+
+	"github.com/mohitmishra786/mdmend/internal/terminology"
 )
 
 type MD037 struct{}
@@ -131,7 +133,8 @@ func (r *MD039) Fix(content string, path string) FixResult {
 }
 
 type MD044 struct {
-	Names []string
+	Names      []string
+	CodeBlocks bool
 }
 
 func init() {
@@ -143,74 +146,47 @@ func (r *MD044) Name() string        { return "proper-names" }
 func (r *MD044) Description() string { return "Proper names should have the correct capitalization" }
 func (r *MD044) Fixable() bool       { return true }
 
-func (r *MD044) Lint(content string, path string) []Violation {
-	var violations []Violation
-	lines := strings.Split(content, "\n")
-	for i, line := range lines {
-		for _, name := range r.Names {
-			if hasImproperCase(line, name) {
-				violations = append(violations, Violation{
-					Rule:      r.ID(),
-					Line:      i + 1,
-					Column:    1,
-					Message:   "Proper name should be " + name,
-					Fixable:   true,
-					Suggested: name,
-				})
-			}
+// vocabulary turns Names into terminology terms. Names are only matched in
+// prose; with CodeBlocks they are also reported, but never fixed, in code.
+func (r *MD044) vocabulary() *terminology.Vocabulary {
+	allowedInCode := !r.CodeBlocks
+	var terms []terminology.Term
+	for _, name := range r.Names {
+		if strings.TrimSpace(name) == "" {
+			continue
 		}
+		terms = append(terms, terminology.Term{
+			Term:          name,
+			AllowedInCode: &allowedInCode,
+			Message:       "Proper name should be " + name,
+		})
 	}
-	return violations
-}
-
-func (r *MD044) Fix(content string, path string) FixResult {
-	fixed := content
-	for _, name := range r.Names {
-		fixed = fixProperCase(fixed, name)
+	v, err := terminology.New(terms)
+	if err != nil {
+		return nil
 	}
-	return FixResult{Changed: fixed != content, Lines: strings.Split(fixed, "\n")}
+	return v
 }
 
-func hasImproperCase(text, properName string) bool {
-	lower := strings.ToLower(properName)
-	idx := 0
-	for {
-		pos := strings.Index(strings.ToLower(text[idx:]), lower)
-		if pos == -1 {
-			return false
-		}
-		start := idx + pos
-		end := start + len(properName)
-		if end <= len(text) {
-			found := text[start:end]
-			if found != properName {
-				return true
-			}
-		}
-		idx = end
+func (r *MD044) Lint(content string, path string) []Violation {
+	var violations []Violation
+	for _, m := range r.vocabulary().Find(content) {
+		violations = append(violations, Violation{
+			Rule:      r.ID(),
+			Line:      m.Line,
+			Column:    m.Start + 1,
+			Message:   m.Message(),
+			Fixable:   m.Fixable,
+			Suggested: m.Term.Term,
+			EndColumn: m.End + 1,
+		})
 	}
+	return violations
 }
 
-func fixProperCase(text, properName string) string {
-	result := text
-	lower := strings.ToLower(properName)
-	idx := 0
-	for {
-		pos := strings.Index(strings.ToLower(result[idx:]), lower)
-		if pos == -1 {
-			break
-		}
-		start := idx + pos
-		end := start + len(properName)
-		if end <= len(result) {
-			found := result[start:end]
-			if found != properName {
-				result = result[:start] + properName + result[end:]
-			}
-		}
-		idx = end
-	}
-	return result
+func (r *MD044) Fix(content string, path string) FixResult {
+	fixed, n := r.vocabulary().Replace(content)
+	return FixResult{Changed: n > 0, Lines: splitLinesKeep(fixed)}
 }
 
 type MD047 struct{}
@@ -60,9 +60,12 @@ func (r *MD045) Fix(content string, path string) FixResult {
 	return FixResult{Changed: false, Lines: strings.Split(content, "\n")}
 }
 
+// MD051 checks that fragment links such as [setup](#setup) name a heading
+// or an HTML id or name attribute. Heading anchors follow SlugStyle.
 type MD051 struct {
 	SuggestClosest bool
 	Aggressive     bool
+	SlugStyle      string
 }
 
 func init() {
@@ -81,7 +84,7 @@ func (r *MD051) Lint(content string, path string) []Violation {
 	var violations []Violation
 	lines := strings.Split(content, "\n")
 
-	validSlugs := collectHeadingSlugs(lines)
+	validSlugs := collectAnchors(content, r.SlugStyle)
 	inCodeBlock := false
 
 	for i, line := range lines {
@@ -131,7 +134,7 @@ func (r *MD051) Fix(content string, path string) FixResult {
 	lines := strings.Split(content, "\n")
 	changed := false
 
-	validSlugs := collectHeadingSlugs(lines)
+	validSlugs := collectAnchors(content, r.SlugStyle)
 	inCodeBlock := false
 
 	for i, line := range lines {
@@ -166,38 +169,8 @@ func (r *MD051) Fix(content string, path string) FixResult {
 	return FixResult{Changed: changed, Lines: lines}
 }
 
-func collectHeadingSlugs(lines []string) map[string]bool {
-	slugs := make(map[string]bool)
-
-	for i, line := range lines {
-		if text, level := extractHeading(line, lines, i); level > 0 && text != "" {
-			slug := textToSlug(text)
-			slugs[slug] = true
-		}
-	}
-
-	return slugs
-}
-
-func textToSlug(text string) string {
-	text = strings.ToLower(text)
-
-	var result []rune
-	for _, r := range text {
-		if (r >= 'a' && r <= 'z') || (r >= '0' && r <= '9') {
-			result = append(result, r)
-		} else if r == ' ' || r == '-' {
-			if len(result) > 0 && result[len(result)-1] != '-' {
-				result = append(result, '-')
-			}
-		}
-	}
-
-	slug := strings.Trim(string(result), "-")
-	return slug
-}
-
 func isValidSlug(fragment string, validSlugs map[string]bool) bool {
+	fragment = unescapeLink(fragment)
 	return validSlugs[fragment] || validSlugs[strings.ToLower(fragment)]
 }
 
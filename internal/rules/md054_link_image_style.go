@@ -2,7 +2,12 @@ package rules
 
 import (
 	"fmt"
+	"regexp"
+	"sort"
 	"strings"
+	"unicode"
+
+	"github.com/mohitmishra786/mdmend/internal/markdown"
 )
 
 type linkStyle int
@@ -203,3 +208,194 @@ func linkStyleName(style linkStyle) string {
 func (r *MD054) Fix(content string, path string) FixResult {
 	return FixResult{Changed: false, Lines: splitLinesKeep(content)}
 }
+
+// docLink is a link found by documentLinks. Dest is resolved through the
+// reference definitions for reference links.
+type docLink struct {
+	line   int
+	column int
+	text   string
+	dest   string
+}
+
+var (
+	linkWithDestRegex = regexp.MustCompile(`\[([^\]]+)\]\(\s*<?([^)\s>]*)>?(?:\s+[^)]*)?\)`)
+	linkWithRefRegex  = regexp.MustCompile(`\[([^\]]+)\]\[([^\]]*)\]`)
+	shortcutRefRegex  = regexp.MustCompile(`\[([^\]]+)\]`)
+)
+
+// documentLinks returns the inline and reference links outside code, in
+// document order. Images are skipped.
+func documentLinks(content string) []docLink {
+	lines := splitLinesKeep(content)
+	fences := collectFences(lines)
+	refDefs := map[string]string{}
+	for _, line := range lines {
+		if m := refDefRegex.FindStringSubmatch(strings.TrimSpace(line)); m != nil {
+			refDefs[strings.ToLower(m[1])] = strings.Trim(m[2], "<>")
+		}
+	}
+
+	var links []docLink
+	for i, line := range lines {
+		lineNum := i + 1
+		if lineInFences(lineNum, fences) || refDefRegex.MatchString(strings.TrimSpace(line)) {
+			continue
+		}
+		spans := markdown.FindInlineCodeSpans(line)
+		// Blank images so badges such as [![alt](img)](url) have empty text.
+		line = imageInlineRegex.ReplaceAllStringFunc(line, func(m string) string {
+			return strings.Repeat(" ", len(m))
+		})
+		var taken [][2]int
+		add := func(start, end int, text, dest string) {
+			if markdown.OverlapsSpan(start, end, spans) {
+				return
+			}
+			for _, t := range taken {
+				if start < t[1] && end > t[0] {
+					return
+				}
+			}
+			taken = append(taken, [2]int{start, end})
+			links = append(links, docLink{line: lineNum, column: start + 1, text: text, dest: dest})
+		}
+
+		for _, m := range linkWithDestRegex.FindAllStringSubmatchIndex(line, -1) {
+			add(m[0], m[1], line[m[2]:m[3]], line[m[4]:m[5]])
+		}
+		for _, m := range linkWithRefRegex.FindAllStringSubmatchIndex(line, -1) {
+			label := line[m[4]:m[5]]
+			if label == "" {
+				label = line[m[2]:m[3]]
+			}
+			if dest, ok := refDefs[strings.ToLower(label)]; ok {
+				add(m[0], m[1], line[m[2]:m[3]], dest)
+			}
+		}
+		for _, m := range shortcutRefRegex.FindAllStringSubmatchIndex(line, -1) {
+			if m[1] < len(line) && strings.ContainsRune("([:", rune(line[m[1]])) {
+				continue
+			}
+			if dest, ok := refDefs[strings.ToLower(line[m[2]:m[3]])]; ok {
+				add(m[0], m[1], line[m[2]:m[3]], dest)
+			}
+		}
+	}
+
+	sort.SliceStable(links, func(a, b int) bool {
+		if links[a].line != links[b].line {
+			return links[a].line < links[b].line
+		}
+		return links[a].column < links[b].column
+	})
+	return links
+}
+
+type MD059 struct {
+	ProhibitedTexts []string
+	Languages       []string
+}
+
+func init() {
+	Register(&MD059{})
+}
+
+func (r *MD059) ID() string          { return "MD059" }
+func (r *MD059) Name() string        { return "descriptive-link-text" }
+func (r *MD059) Description() string { return "Link text should be descriptive" }
+func (r *MD059) Fixable() bool       { return false }
+
+// linkTextPacks are the non-descriptive phrases for each language pack.
+var linkTextPacks = map[string][]string{
+	"en": {"click here", "here", "link", "more", "read more", "learn more", "click", "this link", "this page"},
+	"de": {"hier", "hier klicken", "klicken sie hier", "link", "mehr", "weiterlesen", "mehr erfahren"},
+	"es": {"aquí", "haz clic aquí", "haga clic aquí", "enlace", "más", "leer más", "saber más"},
+	"fr": {"ici", "cliquez ici", "lien", "plus", "en savoir plus", "lire la suite"},
+	"it": {"qui", "clicca qui", "link", "altro", "leggi di più", "scopri di più"},
+	"nl": {"hier", "klik hier", "link", "meer", "lees meer", "meer informatie"},
+	"pt": {"aqui", "clique aqui", "link", "mais", "leia mais", "saiba mais"},
+}
+
+// LinkTextLanguages lists the built-in language packs.
+func LinkTextLanguages() []string {
+	langs := make([]string, 0, len(linkTextPacks))
+	for lang := range linkTextPacks {
+		langs = append(langs, lang)
+	}
+	sort.Strings(langs)
+	return langs
+}
+
+// prohibited returns the normalized banned phrases: ProhibitedTexts plus the
+// configured language packs, which default to English unless
+// ProhibitedTexts is set.
+func (r *MD059) prohibited() map[string]bool {
+	langs := r.Languages
+	if len(langs) == 0 && len(r.ProhibitedTexts) == 0 {
+		langs = []string{"en"}
+	}
+	banned := map[string]bool{}
+	for _, text := range r.ProhibitedTexts {
+		banned[normalizeLinkText(text)] = true
+	}
+	for _, lang := range langs {
+		for _, text := range linkTextPacks[strings.ToLower(lang)] {
+			banned[normalizeLinkText(text)] = true
+		}
+	}
+	return banned
+}
+
+var bareURLTextRegex = regexp.MustCompile(`^(?:[a-z][a-z0-9+.-]*://|www\.)\S+$`)
+
+func (r *MD059) Lint(content string, path string) []Violation {
+	banned := r.prohibited()
+	type target struct {
+		dest string
+		line int
+	}
+	seen := map[string]target{}
+
+	var violations []Violation
+	report := func(l docLink, msg string) {
+		violations = append(violations, Violation{Rule: r.ID(), Line: l.line, Column: l.column, Message: msg})
+	}
+	for _, l := range documentLinks(content) {
+		text := normalizeLinkText(l.text)
+		switch {
+		case text == "":
+			continue
+		case banned[text]:
+			report(l, fmt.Sprintf("Link text %q is not descriptive", strings.TrimSpace(l.text)))
+			continue
+		case bareURLTextRegex.MatchString(strings.TrimSpace(l.text)):
+			report(l, "Link text should describe the target instead of repeating its URL")
+			continue
+		}
+
+		dest := strings.TrimSuffix(l.dest, "/")
+		if prev, ok := seen[text]; !ok {
+			seen[text] = target{dest: dest, line: l.line}
+		} else if prev.dest != dest {
+			report(l, fmt.Sprintf("Link text %q also links to a different target on line %d", strings.TrimSpace(l.text), prev.line))
+		}
+	}
+	return violations
+}
+
+func (r *MD059) Fix(content string, path string) FixResult {
+	return FixResult{Changed: false, Lines: splitLinesKeep(content)}
+}
+
+// normalizeLinkText lowercases text and strips Markdown markup and
+// punctuation, so "**Click here!**" matches "click here".
+func normalizeLinkText(text string) string {
+	var b strings.Builder
+	for _, r := range strings.ToLower(text) {
+		if unicode.IsLetter(r) || unicode.IsDigit(r) || unicode.IsSpace(r) {
+			b.WriteRune(r)
+		}
+	}
+	return strings.Join(strings.Fields(b.String()), " ")
+}
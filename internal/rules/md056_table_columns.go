@@ -1,8 +1,6 @@
 package rules
 
 import (
-	"os"
-	"path/filepath"
 	"regexp"
 	"strings"
 )
@@ -199,112 +197,6 @@ func padTableRow(line string, extraCols int) string {
 	return line + suffix
 }
 
-type MD057 struct {
-	SuggestClosest bool
-}
-
-func init() {
-	Register(&MD057{SuggestClosest: true})
-}
-
-func (r *MD057) ID() string          { return "MD057" }
-func (r *MD057) Name() string        { return "broken-links" }
-func (r *MD057) Description() string { return "Broken relative links should be fixed" }
-func (r *MD057) Fixable() bool       { return false }
-
-var relativeLinkRegex = regexp.MustCompile(`\[([^\]]*)\]\(([^)#][^)]*)\)`)
-
-func (r *MD057) Lint(content string, path string) []Violation {
-	var violations []Violation
-	lines := strings.Split(content, "\n")
-	baseDir := filepath.Dir(path)
-	inCodeBlock := false
-
-	for i, line := range lines {
-		trimmed := strings.TrimSpace(line)
-		if strings.HasPrefix(trimmed, "```") || strings.HasPrefix(trimmed, "~~~") {
-			inCodeBlock = !inCodeBlock
-			continue
-		}
-
-		if inCodeBlock {
-			continue
-		}
-
-		matches := relativeLinkRegex.FindAllStringSubmatchIndex(line, -1)
-		for _, match := range matches {
-			if len(match) >= 6 {
-				linkPath := line[match[4]:match[5]]
-
-				if strings.HasPrefix(linkPath, "http://") || strings.HasPrefix(linkPath, "https://") {
-					continue
-				}
-
-				if strings.HasPrefix(linkPath, "#") {
-					continue
-				}
-
-				anchorPos := strings.Index(linkPath, "#")
-				if anchorPos > 0 {
-					linkPath = linkPath[:anchorPos]
-				}
-
-				targetPath := filepath.Join(baseDir, linkPath)
-				if !fileExists(targetPath) {
-					suggestion := ""
-					if r.SuggestClosest {
-						suggestion = findClosestFile(baseDir, linkPath)
-					}
-					violations = append(violations, Violation{
-						Rule:      r.ID(),
-						Line:      i + 1,
-						Column:    match[4] + 1,
-						Message:   "Broken relative link: " + linkPath,
-						Fixable:   false,
-						Suggested: suggestion,
-					})
-				}
-			}
-		}
-	}
-
-	return violations
-}
-
-func (r *MD057) Fix(content string, path string) FixResult {
-	return FixResult{Changed: false, Lines: strings.Split(content, "\n")}
-}
-
-func fileExists(path string) bool {
-	_, err := os.Stat(path)
-	return err == nil
-}
-
-func findClosestFile(baseDir, targetPath string) string {
-	targetName := filepath.Base(targetPath)
-
-	entries, err := os.ReadDir(baseDir)
-	if err != nil {
-		return ""
-	}
-
-	for _, entry := range entries {
-		if entry.IsDir() {
-			continue
-		}
-
-		if entry.Name() == targetName {
-			return entry.Name()
-		}
-
-		if levenshteinDistance(strings.ToLower(entry.Name()), strings.ToLower(targetName)) <= 2 {
-			return entry.Name()
-		}
-	}
-
-	return ""
-}
-
 type MD043 struct {
 	Headings []string
 }
@@ -0,0 +1,357 @@
+package rules
+
+import (
+	"net/url"
+	"os"
+	"path"
+	"path/filepath"
+	"regexp"
+	"strings"
+
+	"github.com/mohitmishra786/mdmend/internal/config"
+	"github.com/mohitmishra786/mdmend/internal/markdown"
+)
+
+// MD057 checks relative links, images, reference definitions and HTML href
+// and src attributes. The target must exist with the same case as on disk,
+// and a fragment such as other.md#setup must name a heading in the target
+// file. Directory links resolve to the directory's README.md or index.md.
+// Site-absolute paths such as /docs depend on where the site is published
+// and are not checked.
+type MD057 struct {
+	SuggestClosest bool
+	SlugStyle      string
+}
+
+func init() {
+	Register(&MD057{SuggestClosest: true})
+}
+
+func (r *MD057) ID() string          { return "MD057" }
+func (r *MD057) Name() string        { return "broken-links" }
+func (r *MD057) Description() string { return "Broken relative links should be fixed" }
+func (r *MD057) Fixable() bool       { return false }
+
+var (
+	brokenLinkInlineRegex = regexp.MustCompile(`(!?)\[((?:[^\[\]]|\[[^\[\]]*\])*)\]\(\s*(<[^<>]*>|[^\s()<>]*)(?:\s+(?:"[^"]*"|'[^']*'|\([^()]*\)))?\s*\)`)
+	brokenLinkRefDefRegex = regexp.MustCompile(`^ {0,3}\[[^\]^][^\]]*\]:\s*(<[^<>]*>|\S+)`)
+	brokenLinkTagRegex    = regexp.MustCompile(`<([A-Za-z][A-Za-z0-9-]*)\s[^<>]*>`)
+	brokenLinkAttrRegex   = regexp.MustCompile(`\s(?i:(href|src))\s*=\s*(?:"([^"]*)"|'([^']*)'|([^\s"'>]+))`)
+	urlSchemeRegex        = regexp.MustCompile(`^[A-Za-z][A-Za-z0-9+.-]*:`)
+)
+
+// headingIndex holds an anchorIndex for every file a link points into, so
+// each target is parsed once per run however many files link to it.
+var headingIndex fileCache
+
+// dirListings holds directory entries for resolving links case by case.
+var dirListings fileCache
+
+// linkTarget is a link destination: a byte range in a line, without the
+// angle brackets of <dest>.
+type linkTarget struct {
+	line  int
+	start int
+	end   int
+	image bool
+}
+
+func (r *MD057) Lint(content string, path string) []Violation {
+	var violations []Violation
+	lines := strings.Split(content, "\n")
+	baseDir := filepath.Dir(path)
+
+	for _, t := range findLinkTargets(content) {
+		if v, ok := r.check(lines[t.line], t, baseDir, path, lines); ok {
+			violations = append(violations, v)
+		}
+	}
+	return violations
+}
+
+func (r *MD057) Fix(content string, path string) FixResult {
+	return FixResult{Changed: false, Lines: strings.Split(content, "\n")}
+}
+
+func (r *MD057) check(line string, t linkTarget, baseDir, docPath string, lines []string) (Violation, bool) {
+	dest := line[t.start:t.end]
+	if dest == "" || strings.HasPrefix(dest, "#") || strings.HasPrefix(dest, "/") || urlSchemeRegex.MatchString(dest) {
+		return Violation{}, false
+	}
+	rawPath, fragment, fragStart := dest, "", -1
+	if k := strings.IndexAny(dest, "?#"); k >= 0 {
+		rawPath = dest[:k]
+	}
+	if k := strings.Index(dest, "#"); k >= 0 {
+		fragment, fragStart = dest[k+1:], t.start+k+1
+	}
+	if rawPath == "" {
+		return Violation{}, false
+	}
+	linkPath := unescapeLink(rawPath)
+	v := Violation{Rule: r.ID(), Line: t.line + 1, Column: t.start + 1}
+
+	// A link back into the document being checked resolves even when the
+	// document is not saved to disk.
+	self := samePath(filepath.Join(baseDir, filepath.FromSlash(linkPath)), docPath)
+	actual, found, caseDiffers := linkPath, true, false
+	if !self {
+		actual, found, caseDiffers = resolveLinkPath(baseDir, linkPath)
+	}
+	if !found {
+		v.Message = "Broken relative link: " + linkPath
+		if t.image {
+			v.Message = "Missing image: " + linkPath
+		}
+		if r.SuggestClosest {
+			if s := closestLinkPath(baseDir, linkPath); s != "" {
+				v.Suggested = s
+				if rawPath == linkPath {
+					v.EndColumn = t.start + len(rawPath) + 1
+				}
+			}
+		}
+		return v, true
+	}
+	if caseDiffers {
+		v.Message = "Link target case differs from the file on disk: " + linkPath
+		v.Suggested = actual
+		v.Severity = SeverityWarning
+		if rawPath == linkPath {
+			v.EndColumn = t.start + len(rawPath) + 1
+		}
+		return v, true
+	}
+	if fragment == "" {
+		return v, false
+	}
+
+	target := filepath.Join(baseDir, filepath.FromSlash(actual))
+	name := linkPath
+	if info, err := os.Stat(target); !self && err == nil && info.IsDir() {
+		index := directoryIndex(target)
+		if index == "" {
+			v.Message = "No README.md or index.md to resolve #" + fragment + " in directory: " + linkPath
+			return v, true
+		}
+		target = filepath.Join(target, index)
+		name = path.Join(linkPath, index)
+	}
+	if !isMarkdownPath(target) {
+		return v, false
+	}
+	slugs, ok := collectAnchors(strings.Join(lines, "\n"), r.SlugStyle), true
+	if !self {
+		slugs, ok = targetAnchors(target, r.SlugStyle)
+	}
+	if !ok {
+		return v, false
+	}
+	anchor := unescapeLink(fragment)
+	if isValidSlug(anchor, slugs) {
+		return v, false
+	}
+	v.Column = fragStart + 1
+	v.Message = "Link fragment not found in " + name + ": #" + anchor
+	if r.SuggestClosest {
+		if s := findClosestSlug(anchor, slugs); s != "" {
+			v.Suggested = s
+			if anchor == fragment {
+				v.EndColumn = fragStart + len(fragment) + 1
+			}
+		}
+	}
+	return v, true
+}
+
+// findLinkTargets returns the destinations of inline links and images,
+// reference definitions and HTML href and src attributes outside code and
+// HTML comments.
+func findLinkTargets(content string) []linkTarget {
+	var targets []linkTarget
+	for i, line := range markdown.MarkupLines(content) {
+		if m := brokenLinkRefDefRegex.FindStringSubmatchIndex(line); m != nil {
+			targets = append(targets, newLinkTarget(line, i, m[2], m[3], false))
+			continue
+		}
+		targets = appendInlineTargets(targets, line, i, 0, len(line))
+		for _, tag := range brokenLinkTagRegex.FindAllStringSubmatchIndex(line, -1) {
+			img := strings.EqualFold(line[tag[2]:tag[3]], "img")
+			for _, a := range brokenLinkAttrRegex.FindAllStringSubmatchIndex(line[tag[0]:tag[1]], -1) {
+				for g := 4; g+1 < len(a); g += 2 {
+					if a[g] >= 0 {
+						targets = append(targets, linkTarget{i, tag[0] + a[g], tag[0] + a[g+1], img})
+						break
+					}
+				}
+			}
+		}
+	}
+	return targets
+}
+
+// appendInlineTargets appends the links in line[from:to], including images
+// nested in link text such as badges.
+func appendInlineTargets(targets []linkTarget, line string, i, from, to int) []linkTarget {
+	for _, m := range brokenLinkInlineRegex.FindAllStringSubmatchIndex(line[from:to], -1) {
+		targets = appendInlineTargets(targets, line, i, from+m[4], from+m[5])
+		targets = append(targets, newLinkTarget(line, i, from+m[6], from+m[7], m[3] > m[2]))
+	}
+	return targets
+}
+
+func newLinkTarget(line string, i, start, end int, image bool) linkTarget {
+	if end-start >= 2 && line[start] == '<' && line[end-1] == '>' {
+		start, end = start+1, end-1
+	}
+	return linkTarget{i, start, end, image}
+}
+
+func unescapeLink(s string) string {
+	if u, err := url.PathUnescape(s); err == nil {
+		return u
+	}
+	return s
+}
+
+// resolveLinkPath follows the slash-separated rel from base, matching each
+// element case-insensitively when no entry matches exactly. It returns rel
+// with the names as they are on disk.
+func resolveLinkPath(base, rel string) (actual string, found, caseDiffers bool) {
+	cur := base
+	elems := strings.Split(rel, "/")
+	for i, elem := range elems {
+		switch elem {
+		case "", ".":
+			continue
+		case "..":
+			cur = filepath.Join(cur, "..")
+			continue
+		}
+		name, ok := dirEntry(cur, elem)
+		if !ok {
+			return "", false, false
+		}
+		if name != elem {
+			elems[i] = name
+			caseDiffers = true
+		}
+		cur = filepath.Join(cur, name)
+	}
+	return strings.Join(elems, "/"), true, caseDiffers
+}
+
+// dirEntry returns the entry of dir called name, or failing that the one
+// whose name differs only in case.
+func dirEntry(dir, name string) (string, bool) {
+	v, err := dirListings.load(dir, func(abs string) (interface{}, error) {
+		entries, err := os.ReadDir(abs)
+		if err != nil {
+			return nil, err
+		}
+		names := make([]string, len(entries))
+		for i, e := range entries {
+			names[i] = e.Name()
+		}
+		return names, nil
+	})
+	if err != nil {
+		return "", false
+	}
+	names := v.([]string)
+	for _, n := range names {
+		if n == name {
+			return n, true
+		}
+	}
+	for _, n := range names {
+		if strings.EqualFold(n, name) {
+			return n, true
+		}
+	}
+	return "", false
+}
+
+// directoryIndex returns the file a directory link shows: README.md, or
+// index.md for static site generators.
+func directoryIndex(dir string) string {
+	for _, name := range []string{"README.md", "index.md"} {
+		if actual, ok := dirEntry(dir, name); ok {
+			return actual
+		}
+	}
+	return ""
+}
+
+func isMarkdownPath(p string) bool {
+	ext := strings.ToLower(filepath.Ext(p))
+	for _, e := range config.DefaultExtensions {
+		if ext == e {
+			return true
+		}
+	}
+	return false
+}
+
+func samePath(a, b string) bool {
+	absA, errA := filepath.Abs(a)
+	absB, errB := filepath.Abs(b)
+	return errA == nil && errB == nil && absA == absB
+}
+
+// targetAnchors returns the anchors of the file at target under style.
+func targetAnchors(target, style string) (map[string]bool, bool) {
+	v, err := headingIndex.load(target, func(abs string) (interface{}, error) {
+		data, err := os.ReadFile(abs)
+		if err != nil {
+			return nil, err
+		}
+		return &anchorIndex{content: string(data), styles: make(map[string]map[string]bool)}, nil
+	})
+	if err != nil {
+		return nil, false
+	}
+	return v.(*anchorIndex).anchors(style), true
+}
+
+// closestLinkPath suggests a file in the link's directory whose name is
+// close to the missing one.
+func closestLinkPath(base, linkPath string) string {
+	dir, name := path.Split(linkPath)
+	searchDir := base
+	if dir != "" {
+		actual, found, _ := resolveLinkPath(base, dir)
+		if !found {
+			return ""
+		}
+		dir = actual
+		searchDir = filepath.Join(base, filepath.FromSlash(actual))
+	}
+	if closest := findClosestFile(searchDir, name); closest != "" {
+		return dir + closest
+	}
+	return ""
+}
+
+func findClosestFile(baseDir, targetName string) string {
+	entries, err := os.ReadDir(baseDir)
+	if err != nil {
+		return ""
+	}
+
+	for _, entry := range entries {
+		if entry.IsDir() {
+			continue
+		}
+
+		if entry.Name() == targetName {
+			return entry.Name()
+		}
+
+		if levenshteinDistance(strings.ToLower(entry.Name()), strings.ToLower(targetName)) <= 2 {
+			return entry.Name()
+		}
+	}
+
+	return ""
+}
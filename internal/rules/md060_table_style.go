@@ -0,0 +1,377 @@
+package rules
+
+import (
+	"strings"
+
+	"github.com/mohitmishra786/mdmend/internal/markdown"
+)
+
+// MD060 checks the spacing around table cells. Style is one of "aligned"
+// (pipes line up), "compact" (one space around each cell), "tight" (no
+// spaces) or "any" (each table consistently uses one of them).
+type MD060 struct {
+	Style string
+}
+
+func init() {
+	Register(&MD060{Style: "any"})
+}
+
+func (r *MD060) ID() string          { return "MD060" }
+func (r *MD060) Name() string        { return "table-column-style" }
+func (r *MD060) Description() string { return "Table column style" }
+func (r *MD060) Fixable() bool       { return true }
+
+func (r *MD060) Lint(content string, path string) []Violation {
+	var violations []Violation
+	lines := strings.Split(content, "\n")
+
+	for _, t := range findTables(lines) {
+		for _, p := range r.check(t) {
+			violations = append(violations, Violation{
+				Rule:    r.ID(),
+				Line:    t.start + p.row + 1,
+				Column:  1,
+				Message: p.message,
+				Fixable: true,
+			})
+		}
+	}
+	return violations
+}
+
+func (r *MD060) Fix(content string, path string) FixResult {
+	lines := strings.Split(content, "\n")
+	changed := false
+
+	for _, t := range findTables(lines) {
+		if len(r.check(t)) == 0 {
+			continue
+		}
+		for i, row := range t.format(r.target(t)) {
+			if lines[t.start+i] != row {
+				lines[t.start+i] = row
+				changed = true
+			}
+		}
+	}
+	return FixResult{Changed: changed, Lines: lines}
+}
+
+type tableProblem struct {
+	row     int
+	message string
+}
+
+// target returns the style t should be formatted in. With "any", that is
+// the style of the header row.
+func (r *MD060) target(t mdTable) string {
+	switch r.Style {
+	case "aligned", "compact", "tight":
+		return r.Style
+	}
+	for _, style := range []string{"compact", "tight"} {
+		if t.rowMatches(0, style, false) {
+			return style
+		}
+	}
+	return "aligned"
+}
+
+func (r *MD060) check(t mdTable) []tableProblem {
+	style := r.target(t)
+	// With "any" the delimiter row is not checked: |---| under a compact
+	// header is common enough to count as consistent.
+	strict := style == r.Style
+	if !strict && (t.aligned() || t.allMatch("compact") || t.allMatch("tight")) {
+		return nil
+	}
+
+	var problems []tableProblem
+	if style == "aligned" {
+		if t.aligned() {
+			return nil
+		}
+		for i, row := range t.format(style) {
+			if strings.TrimRight(t.lines[i], " \t") != row {
+				problems = append(problems, tableProblem{i, "Table pipes should be aligned"})
+			}
+		}
+		return problems
+	}
+
+	for i := range t.rows {
+		if i == 1 && !strict || t.rowMatches(i, style, strict) {
+			continue
+		}
+		message := "Table cells should be padded with one space"
+		if style == "tight" {
+			message = "Table cells should not be padded"
+		}
+		if i == 1 && t.rowMatches(i, style, false) {
+			message = "Table delimiter row should use ---, :---, ---: or :---:"
+		}
+		problems = append(problems, tableProblem{i, message})
+	}
+	return problems
+}
+
+// mdTable is a GFM table: a header row, a delimiter row and any body rows.
+type mdTable struct {
+	start  int
+	indent string
+	lead   bool
+	trail  bool
+	lines  []string
+	rows   []tableRow
+	align  []string
+}
+
+type tableRow struct {
+	cells []string
+	pipes []int
+	lead  bool
+	trail bool
+}
+
+// findTables returns the tables outside fenced code whose rows all have as
+// many cells as the header. Tables with ragged rows are left to MD056.
+func findTables(lines []string) []mdTable {
+	var tables []mdTable
+	fences := collectFences(lines)
+
+	for i := 0; i+1 < len(lines); i++ {
+		header, delimiter := lines[i], lines[i+1]
+		if lineInFences(i+1, fences) || !strings.Contains(header, "|") ||
+			!strings.Contains(delimiter, "|") || !isTableSeparatorRow(delimiter) {
+			continue
+		}
+		indent := header[:len(header)-len(strings.TrimLeft(header, " "))]
+		if len(indent) > 3 {
+			continue
+		}
+
+		t := mdTable{start: i, indent: indent}
+		end := i + 2
+		for end < len(lines) && strings.TrimSpace(lines[end]) != "" &&
+			strings.Contains(lines[end], "|") && !lineInFences(end+1, fences) {
+			end++
+		}
+		t.lines = lines[i:end]
+		ragged := false
+		for _, line := range t.lines {
+			row := splitTableRow(line)
+			if len(t.rows) > 0 && len(row.cells) != len(t.rows[0].cells) {
+				ragged = true
+			}
+			t.rows = append(t.rows, row)
+		}
+		i = end - 1
+		if ragged {
+			continue
+		}
+
+		t.lead, t.trail = t.rows[0].lead, t.rows[0].trail
+		for _, cell := range t.rows[1].cells {
+			cell = strings.TrimSpace(cell)
+			left, right := strings.HasPrefix(cell, ":"), strings.HasSuffix(cell, ":")
+			switch {
+			case left && right:
+				t.align = append(t.align, "center")
+			case left:
+				t.align = append(t.align, "left")
+			case right:
+				t.align = append(t.align, "right")
+			default:
+				t.align = append(t.align, "")
+			}
+		}
+		tables = append(tables, t)
+	}
+	return tables
+}
+
+// splitTableRow splits line into raw cells and records the display column of
+// every cell pipe. As in GFM, every unescaped pipe splits cells, including
+// one in inline code, which must be written \| to stay in its cell.
+func splitTableRow(line string) tableRow {
+	s := strings.TrimRight(line, " \t")
+	start := len(s) - len(strings.TrimLeft(s, " \t"))
+
+	var row tableRow
+	var bounds []int
+	for i := start; i < len(s); i++ {
+		switch {
+		case s[i] == '\\':
+			i++
+		case s[i] == '|':
+			bounds = append(bounds, i)
+			row.pipes = append(row.pipes, markdown.DisplayWidth(s[:i]))
+		}
+	}
+
+	row.lead = len(bounds) > 0 && bounds[0] == start
+	row.trail = len(bounds) > 0 && bounds[len(bounds)-1] == len(s)-1 && len(s)-1 > start
+	cellStart := start
+	for i, b := range bounds {
+		if i == 0 && row.lead {
+			cellStart = b + 1
+			continue
+		}
+		row.cells = append(row.cells, s[cellStart:b])
+		cellStart = b + 1
+	}
+	if !row.trail {
+		row.cells = append(row.cells, s[cellStart:])
+	}
+	return row
+}
+
+// aligned reports whether every row has its pipes in the same columns.
+func (t mdTable) aligned() bool {
+	first := t.rows[0].pipes
+	for _, row := range t.rows[1:] {
+		if len(row.pipes) != len(first) {
+			return false
+		}
+		for i, col := range row.pipes {
+			if col != first[i] {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+// allMatch reports whether every row but the delimiter row uses style.
+func (t mdTable) allMatch(style string) bool {
+	for i := range t.rows {
+		if i != 1 && !t.rowMatches(i, style, false) {
+			return false
+		}
+	}
+	return true
+}
+
+// rowMatches reports whether row i uses the compact or tight style. When
+// strict is set, delimiter cells must also be written as ---, :---, ---: or
+// :---:.
+func (t mdTable) rowMatches(i int, style string, strict bool) bool {
+	row := t.rows[i]
+	if row.lead != t.lead || row.trail != t.trail {
+		return false
+	}
+	for k, cell := range row.cells {
+		text := strings.Trim(cell, " \t")
+		if i == 1 && strict && text != delimiterCell(t.align[k], 0) {
+			return false
+		}
+		left := cell[:len(cell)-len(strings.TrimLeft(cell, " \t"))]
+		right := cell[len(strings.TrimRight(cell, " \t")):]
+		if text == "" {
+			if style == "tight" && cell != "" || style == "compact" && cell != " " && cell != "  " {
+				return false
+			}
+			continue
+		}
+		wantLeft, wantRight := " ", " "
+		if style == "tight" {
+			wantLeft, wantRight = "", ""
+		}
+		if k == 0 && !row.lead {
+			wantLeft = ""
+		}
+		if k == len(row.cells)-1 && !row.trail {
+			wantRight = ""
+		}
+		if left != wantLeft || right != wantRight {
+			return false
+		}
+	}
+	return true
+}
+
+// format renders t in style, keeping the indentation and outer pipes of the
+// header row.
+func (t mdTable) format(style string) []string {
+	widths := make([]int, len(t.align))
+	if style == "aligned" {
+		for k, align := range t.align {
+			widths[k] = len(delimiterCell(align, 0))
+		}
+		for i, row := range t.rows {
+			if i == 1 {
+				continue
+			}
+			for k, cell := range row.cells {
+				if w := markdown.DisplayWidth(strings.Trim(cell, " \t")); w > widths[k] {
+					widths[k] = w
+				}
+			}
+		}
+	}
+
+	sep, opening, closing := " | ", "| ", " |"
+	if style == "tight" {
+		sep, opening, closing = "|", "|", "|"
+	}
+
+	out := make([]string, len(t.rows))
+	for i, row := range t.rows {
+		cells := make([]string, len(row.cells))
+		for k, cell := range row.cells {
+			if i == 1 {
+				cells[k] = delimiterCell(t.align[k], widths[k])
+				continue
+			}
+			cells[k] = padCell(strings.Trim(cell, " \t"), widths[k], t.align[k])
+		}
+		line := strings.Join(cells, sep)
+		if t.lead {
+			line = opening + line
+		}
+		if t.trail {
+			line += closing
+		} else {
+			line = strings.TrimRight(line, " ")
+		}
+		out[i] = t.indent + line
+	}
+	return out
+}
+
+// delimiterCell writes a delimiter cell for align, filled with dashes to
+// width.
+func delimiterCell(align string, width int) string {
+	cell := "---"
+	switch align {
+	case "left":
+		cell = ":---"
+	case "right":
+		cell = "---:"
+	case "center":
+		cell = ":---:"
+	}
+	if extra := width - len(cell); extra > 0 {
+		dashes := strings.Repeat("-", extra)
+		if align == "right" {
+			return dashes + cell
+		}
+		return cell[:1] + dashes + cell[1:]
+	}
+	return cell
+}
+
+func padCell(text string, width int, align string) string {
+	extra := width - markdown.DisplayWidth(text)
+	if extra <= 0 {
+		return text
+	}
+	switch align {
+	case "right":
+		return strings.Repeat(" ", extra) + text
+	case "center":
+		return strings.Repeat(" ", extra/2) + text + strings.Repeat(" ", extra-extra/2)
+	}
+	return text + strings.Repeat(" ", extra)
+}
@@ -2,14 +2,30 @@ package rules
 
 import (
 	"fmt"
+	"regexp"
 	"strings"
 )
 
+// MD073 keeps the table of contents between <!-- toc --> and <!-- /toc -->
+// markers, or doctoc's START and END markers, in step with the headings
+// after it. Fix regenerates the list as a nested list of links; with Insert
+// it also adds a TOC after the first heading of a document without one.
 type MD073 struct {
 	Enabled      bool
 	MinLevel     int
 	MaxLevel     int
 	EnforceOrder bool
+	SlugStyle    string
+	// Style is the list marker: dash, asterisk, plus or ordered.
+	Style string
+	// Indent is the number of spaces per nesting level of a bulleted TOC,
+	// as MD007 expects. Ordered items nest under their parent's text.
+	Indent int
+	Insert bool
+	// CheckFormat reports a TOC whose entries are right but whose markers
+	// or indent differ from the generated list. Configure sets it when
+	// MD073 sets its own style or indent.
+	CheckFormat bool
 }
 
 func init() {
@@ -18,6 +34,8 @@ func init() {
 		MinLevel:     2,
 		MaxLevel:     4,
 		EnforceOrder: true,
+		Style:        "dash",
+		Indent:       2,
 	})
 }
 
@@ -26,6 +44,8 @@ func (r *MD073) Name() string        { return "toc-validation" }
 func (r *MD073) Description() string { return "Table of contents entries must match document headings" }
 func (r *MD073) Fixable() bool       { return true }
 
+var tocListItemRegex = regexp.MustCompile(`^\s*(?:[-*+]|\d+[.)])\s`)
+
 type tocRegion struct {
 	start int
 	stop  int
@@ -39,17 +59,48 @@ func (r *MD073) Lint(content string, path string) []Violation {
 	lines := splitLinesKeep(content)
 	region, ok := findTOCRegion(lines)
 	if !ok {
+		if !r.Insert {
+			return nil
+		}
+		if at, ok := r.insertionPoint(lines); ok && len(r.collectHeadings(lines, at)) > 0 {
+			return []Violation{{
+				Rule:    r.ID(),
+				Line:    at,
+				Column:  1,
+				Message: "Document has no table of contents",
+				Fixable: true,
+			}}
+		}
 		return nil
 	}
 
 	headings := r.collectHeadings(lines, region.stop)
 	tocEntries := r.collectTOCEntries(lines, region.start, region.stop)
-	return r.diffTOC(headings, tocEntries)
+	violations := r.diffTOC(headings, tocEntries)
+	if len(violations) == 0 && r.CheckFormat {
+		_, list, _ := splitTOCRegion(lines, region)
+		if strings.Join(list, "\n") != strings.Join(r.tocLines(headings), "\n") {
+			violations = append(violations, Violation{
+				Rule:    r.ID(),
+				Line:    region.start + 1,
+				Column:  1,
+				Message: "Table of contents formatting does not match the generated list",
+				Fixable: true,
+			})
+		}
+	}
+	return violations
 }
 
+// findTOCRegion returns the lines between the first pair of TOC markers
+// outside code fences.
 func findTOCRegion(lines []string) (tocRegion, bool) {
+	fences := collectFences(lines)
 	start := -1
 	for i, line := range lines {
+		if lineInFences(i+1, fences) {
+			continue
+		}
 		if tocMarkerStartRegex.MatchString(line) {
 			start = i + 1
 			continue
@@ -61,6 +112,21 @@ func findTOCRegion(lines []string) (tocRegion, bool) {
 	return tocRegion{}, false
 }
 
+// splitTOCRegion separates the lines before the first list item, such as
+// doctoc's title, and the blank lines after the list from the list itself,
+// so that regenerating the list keeps them.
+func splitTOCRegion(lines []string, region tocRegion) (head, list, tail []string) {
+	end := region.stop
+	for end > region.start && strings.TrimSpace(lines[end-1]) == "" {
+		end--
+	}
+	first := region.start
+	for first < end && !tocListItemRegex.MatchString(lines[first]) {
+		first++
+	}
+	return lines[region.start:first], lines[first:end], lines[end:region.stop]
+}
+
 type headingEntry struct {
 	line   int
 	level  int
@@ -68,8 +134,9 @@ type headingEntry struct {
 	anchor string
 }
 
+// collectHeadings returns the headings within the level range that start at
+// or after the 0-based line index after.
 func (r *MD073) collectHeadings(lines []string, after int) []headingEntry {
-	fences := collectFences(lines)
 	minLevel := r.MinLevel
 	maxLevel := r.MaxLevel
 	if minLevel <= 0 {
@@ -80,32 +147,24 @@ func (r *MD073) collectHeadings(lines []string, after int) []headingEntry {
 	}
 
 	var entries []headingEntry
-	anchorCounts := map[string]int{}
-
-	for i := after; i < len(lines); i++ {
-		lineNum := i + 1
-		if lineInFences(lineNum, fences) {
-			continue
-		}
-		text, level := extractHeading(lines[i], lines, i)
-		if level == 0 || level < minLevel || level > maxLevel {
+	for _, h := range collectHeadingAnchors(strings.Join(lines, "\n"), r.SlugStyle) {
+		if h.line <= after || h.level < minLevel || h.level > maxLevel {
 			continue
 		}
-		anchor := slugifyHeading(text)
-		if n := anchorCounts[anchor]; n > 0 {
-			anchor = fmt.Sprintf("%s-%d", anchor, n)
-		}
-		anchorCounts[slugifyHeading(text)]++
 		entries = append(entries, headingEntry{
-			line:   lineNum,
-			level:  level,
-			text:   text,
-			anchor: "#" + anchor,
+			line:   h.line,
+			level:  h.level,
+			text:   tocLinkRegex.ReplaceAllString(h.text, "$1"),
+			anchor: "#" + h.anchor,
 		})
 	}
 	return entries
 }
 
+// tocLinkRegex matches links in heading text, which cannot be nested in a
+// TOC link.
+var tocLinkRegex = regexp.MustCompile(`!?\[([^\]]*)\]\([^)]*\)`)
+
 func (r *MD073) collectTOCEntries(lines []string, start, stop int) []headingEntry {
 	var entries []headingEntry
 	for i := start; i < stop; i++ {
@@ -185,31 +244,144 @@ func (r *MD073) diffTOC(headings, toc []headingEntry) []Violation {
 	return violations
 }
 
+// tocLines renders headings as a nested list. A heading that skips levels
+// nests one level below the previous one, so the list never jumps more
+// than one indent at a time.
+func (r *MD073) tocLines(headings []headingEntry) []string {
+	type listLevel struct {
+		level  int
+		indent int
+		width  int
+		count  int
+	}
+	indent := r.Indent
+	if indent <= 0 {
+		indent = 2
+	}
+
+	var out []string
+	var stack []listLevel
+	for _, h := range headings {
+		for len(stack) > 0 && stack[len(stack)-1].level > h.level {
+			stack = stack[:len(stack)-1]
+		}
+		if len(stack) == 0 || stack[len(stack)-1].level < h.level {
+			next := listLevel{level: h.level}
+			if len(stack) > 0 {
+				parent := stack[len(stack)-1]
+				next.indent = parent.indent + indent
+				if r.Style == "ordered" {
+					next.indent = parent.indent + parent.width
+				}
+			}
+			stack = append(stack, next)
+		}
+		item := &stack[len(stack)-1]
+		item.count++
+		marker := r.bullet()
+		if r.Style == "ordered" {
+			marker = fmt.Sprintf("%d.", item.count)
+		}
+		item.width = len(marker) + 1
+		out = append(out, fmt.Sprintf("%s%s [%s](%s)", strings.Repeat(" ", item.indent), marker, h.text, h.anchor))
+	}
+	return out
+}
+
+func (r *MD073) bullet() string {
+	switch r.Style {
+	case "asterisk":
+		return "*"
+	case "plus":
+		return "+"
+	default:
+		return "-"
+	}
+}
+
+// insertionPoint returns the 0-based index of the line after the first
+// heading, where Insert adds a TOC.
+func (r *MD073) insertionPoint(lines []string) (int, bool) {
+	headings := collectHeadingAnchors(strings.Join(lines, "\n"), r.SlugStyle)
+	if len(headings) == 0 {
+		return 0, false
+	}
+	at := headings[0].line
+	if !strings.HasPrefix(strings.TrimSpace(lines[at-1]), "#") {
+		at++ // after the setext underline
+	}
+	return at, true
+}
+
+// Generate returns the table of contents for content as list lines: the
+// headings after its TOC markers, or after its first heading when it has
+// no markers.
+func (r *MD073) Generate(content string) []string {
+	lines := splitLinesKeep(content)
+	after := 0
+	if region, ok := findTOCRegion(lines); ok {
+		after = region.stop
+	} else if at, ok := r.insertionPoint(lines); ok {
+		after = at
+	}
+	return r.tocLines(r.collectHeadings(lines, after))
+}
+
 func (r *MD073) Fix(content string, path string) FixResult {
-	if !r.Enabled {
+	if !r.Enabled || (!r.CheckFormat && len(r.Lint(content, path)) == 0) {
 		return FixResult{Changed: false, Lines: splitLinesKeep(content)}
 	}
+	return r.Update(content, r.Insert)
+}
 
+// Update regenerates the TOC in content. When content has no TOC markers
+// and insert is set, a TOC is added after the first heading.
+func (r *MD073) Update(content string, insert bool) FixResult {
 	lines := splitLinesKeep(content)
 	region, ok := findTOCRegion(lines)
 	if !ok {
-		return FixResult{Changed: false, Lines: lines}
+		if !insert {
+			return FixResult{Changed: false, Lines: lines}
+		}
+		return r.insert(lines)
 	}
 
-	headings := r.collectHeadings(lines, region.stop)
-	var tocLines []string
-	for _, h := range headings {
-		indent := strings.Repeat(" ", (h.level-r.MinLevel)*2)
-		if indent == "" && h.level > r.MinLevel {
-			indent = strings.Repeat(" ", (h.level-1)*2)
-		}
-		tocLines = append(tocLines, fmt.Sprintf("%s- [%s](%s)", indent, h.text, h.anchor))
+	head, list, tail := splitTOCRegion(lines, region)
+	tocLines := r.tocLines(r.collectHeadings(lines, region.stop))
+	if strings.Join(list, "\n") == strings.Join(tocLines, "\n") {
+		return FixResult{Changed: false, Lines: lines}
 	}
 
-	newLines := make([]string, 0, len(lines)-(region.stop-region.start)+len(tocLines))
+	newLines := make([]string, 0, len(lines)-len(list)+len(tocLines))
 	newLines = append(newLines, lines[:region.start]...)
+	newLines = append(newLines, head...)
 	newLines = append(newLines, tocLines...)
+	newLines = append(newLines, tail...)
 	newLines = append(newLines, lines[region.stop:]...)
 
 	return FixResult{Changed: true, Lines: newLines}
 }
+
+func (r *MD073) insert(lines []string) FixResult {
+	at, ok := r.insertionPoint(lines)
+	if !ok {
+		return FixResult{Changed: false, Lines: lines}
+	}
+	tocLines := r.tocLines(r.collectHeadings(lines, at))
+	if len(tocLines) == 0 {
+		return FixResult{Changed: false, Lines: lines}
+	}
+
+	block := []string{"", "<!-- toc -->", ""}
+	block = append(block, tocLines...)
+	block = append(block, "", "<!-- /toc -->")
+	if at < len(lines) && strings.TrimSpace(lines[at]) != "" {
+		block = append(block, "")
+	}
+
+	newLines := make([]string, 0, len(lines)+len(block))
+	newLines = append(newLines, lines[:at]...)
+	newLines = append(newLines, block...)
+	newLines = append(newLines, lines[at:]...)
+	return FixResult{Changed: true, Lines: newLines}
+}
@@ -0,0 +1,82 @@
+package rules
+
+import (
+	"os"
+	"strings"
+
+	"github.com/mohitmishra786/mdmend/internal/terminology"
+)
+
+type MD074 struct {
+	Vocabulary string
+}
+
+func init() {
+	Register(&MD074{})
+}
+
+func (r *MD074) ID() string          { return "MD074" }
+func (r *MD074) Name() string        { return "terminology" }
+func (r *MD074) Description() string { return "Terms should follow the project vocabulary" }
+func (r *MD074) Fixable() bool       { return true }
+
+func (r *MD074) Lint(content string, path string) []Violation {
+	v, err := r.vocabulary()
+	if err != nil {
+		return []Violation{{
+			Rule:    r.ID(),
+			Line:    1,
+			Column:  1,
+			Message: "Cannot load vocabulary: " + err.Error(),
+		}}
+	}
+
+	var violations []Violation
+	for _, m := range v.Find(content) {
+		violations = append(violations, Violation{
+			Rule:      r.ID(),
+			Line:      m.Line,
+			Column:    m.Start + 1,
+			Message:   m.Message(),
+			Fixable:   m.Fixable,
+			Suggested: m.Term.Term,
+			EndColumn: m.End + 1,
+			Severity:  Severity(strings.ToLower(m.Term.Severity)),
+		})
+	}
+	return violations
+}
+
+func (r *MD074) Fix(content string, path string) FixResult {
+	v, err := r.vocabulary()
+	if err != nil {
+		return FixResult{Changed: false, Lines: splitLinesKeep(content)}
+	}
+	fixed, n := v.Replace(content)
+	return FixResult{Changed: n > 0, Lines: splitLinesKeep(fixed)}
+}
+
+// vocabulary loads the configured file, or DefaultPath when it exists. A
+// nil vocabulary matches nothing.
+func (r *MD074) vocabulary() (*terminology.Vocabulary, error) {
+	path := r.Vocabulary
+	if path == "" {
+		if _, err := os.Stat(terminology.DefaultPath); err != nil {
+			return nil, nil
+		}
+		path = terminology.DefaultPath
+	}
+	return loadVocabulary(path)
+}
+
+var vocabularies fileCache
+
+func loadVocabulary(path string) (*terminology.Vocabulary, error) {
+	v, err := vocabularies.load(path, func(abs string) (interface{}, error) {
+		return terminology.Load(abs)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return v.(*terminology.Vocabulary), nil
+}
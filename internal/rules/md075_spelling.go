@@ -0,0 +1,132 @@
+package rules
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+
+	"github.com/mohitmishra786/mdmend/internal/spelling"
+)
+
+type MD075 struct {
+	Dictionaries []string
+	Words        []string
+}
+
+func init() {
+	Register(&MD075{})
+}
+
+func (r *MD075) ID() string          { return "MD075" }
+func (r *MD075) Name() string        { return "spelling" }
+func (r *MD075) Description() string { return "Words should be spelled correctly" }
+func (r *MD075) Fixable() bool       { return false }
+
+func (r *MD075) Lint(content string, path string) []Violation {
+	checker, err := r.checker(path)
+	if err != nil {
+		return []Violation{{
+			Rule:    r.ID(),
+			Line:    1,
+			Column:  1,
+			Message: "Cannot load dictionary: " + err.Error(),
+		}}
+	}
+
+	var violations []Violation
+	for _, m := range checker.Check(content) {
+		v := Violation{
+			Rule:    r.ID(),
+			Line:    m.Line,
+			Column:  m.Start + 1,
+			Message: "Unknown word \"" + m.Word + "\"",
+		}
+		if len(m.Suggestions) > 0 {
+			v.Suggested = m.Suggestions[0]
+			v.EndColumn = m.End + 1
+		}
+		if len(m.Suggestions) > 1 {
+			v.Message += " (or " + strings.Join(m.Suggestions[1:], ", ") + ")"
+		}
+		violations = append(violations, v)
+	}
+	return violations
+}
+
+func (r *MD075) Fix(content string, path string) FixResult {
+	return FixResult{Changed: false, Lines: splitLinesKeep(content)}
+}
+
+// checkers holds one checker per combination of words and dictionaries, so
+// suggestions worked out for one document are reused for the next.
+var checkers sync.Map
+
+// checker stacks the English word list, the configured words and
+// dictionaries, and every .mdmend-words.txt from the document's directory
+// up to the filesystem root.
+func (r *MD075) checker(path string) (*spelling.Checker, error) {
+	var dicts []*spelling.Dictionary
+	for _, p := range r.Dictionaries {
+		d, err := loadDictionary(p)
+		if err != nil {
+			return nil, err
+		}
+		dicts = append(dicts, d)
+	}
+
+	dir := "."
+	if path != "" && path != "-" && path != "<stdin>" {
+		dir = filepath.Dir(path)
+	}
+	dir, err := filepath.Abs(dir)
+	if err != nil {
+		return nil, err
+	}
+	for {
+		wordsFile := filepath.Join(dir, spelling.WordsFile)
+		if _, err := os.Stat(wordsFile); err == nil {
+			d, err := loadDictionary(wordsFile)
+			if err != nil {
+				return nil, err
+			}
+			dicts = append(dicts, d)
+		}
+		parent := filepath.Dir(dir)
+		if parent == dir {
+			break
+		}
+		dir = parent
+	}
+
+	// Loaded dictionaries are shared until their file changes, so their
+	// addresses identify the stack.
+	key := strings.Join(r.Words, "\n")
+	for _, d := range dicts {
+		key += fmt.Sprintf("\x00%p", d)
+	}
+	if c, ok := checkers.Load(key); ok {
+		return c.(*spelling.Checker), nil
+	}
+	stack := append([]*spelling.Dictionary{spelling.English(), spelling.Words(r.Words...)}, dicts...)
+	c, _ := checkers.LoadOrStore(key, spelling.NewChecker(stack...))
+	return c.(*spelling.Checker), nil
+}
+
+var dictionaries fileCache
+
+// loadDictionary reads a Hunspell .dic file (with its .aff) or a plain word
+// list.
+func loadDictionary(path string) (*spelling.Dictionary, error) {
+	d, err := dictionaries.load(path, func(abs string) (interface{}, error) {
+		if strings.EqualFold(filepath.Ext(abs), ".dic") {
+			return spelling.LoadHunspell(abs)
+		}
+		return spelling.LoadWords(abs)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return d.(*spelling.Dictionary), nil
+}
@@ -0,0 +1,401 @@
+package rules
+
+import (
+	"regexp"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	"github.com/mohitmishra786/mdmend/internal/markdown"
+	"github.com/mohitmishra786/mdmend/internal/spelling"
+)
+
+// DefaultSmallWords are the words title case leaves lowercase unless they
+// start or end a heading.
+var DefaultSmallWords = []string{
+	"a", "an", "and", "as", "at", "but", "by", "for", "from", "if", "in", "into",
+	"nor", "of", "on", "onto", "or", "per", "so", "the", "to", "up", "via", "vs",
+	"with", "yet",
+}
+
+// lowercaseWords are English words that are never names, so sentence case
+// can lower them safely. Other capitalised English words, such as Go,
+// Python or Monday, may be names and are reported for review instead.
+var lowercaseWords = func() map[string]bool {
+	m := make(map[string]bool)
+	for _, w := range strings.Fields(`
+		a about above across after again against all also an and any are as at
+		be because been before being below between both but by can cannot could
+		did do does doing done down during each either every few for from further
+		had has have having her here hers him his how if in into is it its itself
+		just less many more most much my neither no nor not now of off on once
+		only onto or other our out over own per same she should since so some
+		such than that the their them then there these they this those through
+		to too under until up upon very via vs was we were what when where which
+		while who whom whose why with within without would yet you your
+		add adding added build building built change changes changing check
+		checking choose choosing configure configuring configured configuration
+		create creating created define defining delete deleting deploy deploying
+		deployment enable enabling disable disabling find finding get getting
+		handle handling install installing installed installation learn learning
+		make making manage managing migrate migrating move moving remove removing
+		removed rename run running set setting settings setup start started
+		starting stop stopping update updating updated upgrade upgrading use
+		used using work working write writing
+		advanced basics command commands common details example examples
+		features file files first guide introduction issues known last
+		next notes options overview prerequisites reference release releases
+		requirements steps support troubleshooting usage
+	`) {
+		m[w] = true
+	}
+	return m
+}()
+
+// MD076 checks the capitalisation of headings. Style is "sentence", "title"
+// or "preserve", which only requires a capital first letter. Names, acronyms
+// and mixed-case words such as iOS keep their casing, as does inline code.
+// Sentence case only lowers words that are never names; other capitalised
+// English words are reported without being changed.
+type MD076 struct {
+	Style      string
+	SmallWords []string
+	Names      []string
+}
+
+func init() {
+	Register(&MD076{Style: "sentence"})
+}
+
+func (r *MD076) ID() string          { return "MD076" }
+func (r *MD076) Name() string        { return "heading-case" }
+func (r *MD076) Description() string { return "Headings should use the configured capitalisation" }
+func (r *MD076) Fixable() bool       { return true }
+
+var (
+	headingCaseATXRegex     = regexp.MustCompile(`^ {0,3}#{1,6}(?:[ \t]+|$)`)
+	headingCaseClosing      = regexp.MustCompile(`[ \t]+#+[ \t]*$`)
+	headingCaseSetextRegex  = regexp.MustCompile(`^ {0,3}(?:=+|-+)[ \t]*$`)
+	headingCaseOrderedRegex = regexp.MustCompile(`^\d{1,9}[.)](?:[ \t]|$)`)
+)
+
+type headingText struct {
+	line  int
+	start int
+	end   int
+}
+
+func (r *MD076) Lint(content string, path string) []Violation {
+	var violations []Violation
+	lines := strings.Split(content, "\n")
+	prose := markdown.ProseLines(content)
+	known := spelling.NewChecker(spelling.English()).Known
+
+	for _, h := range findHeadingTexts(lines, prose) {
+		text := lines[h.line][h.start:h.end]
+		want, review := r.recase(text, prose[h.line][h.start:h.end], known)
+		if want == text && len(review) == 0 {
+			continue
+		}
+		v := Violation{
+			Rule:      r.ID(),
+			Line:      h.line + 1,
+			Column:    h.start + 1,
+			Message:   "Heading should use " + r.style() + " case",
+			EndColumn: h.end + 1,
+		}
+		if want != text {
+			v.Message += ": \"" + want + "\""
+			v.Fixable = true
+			v.Suggested = want
+		}
+		if len(review) > 0 {
+			v.Message += "; check whether " + quoteWords(review) + " should be lowercase"
+		}
+		violations = append(violations, v)
+	}
+	return violations
+}
+
+func (r *MD076) Fix(content string, path string) FixResult {
+	lines := strings.Split(content, "\n")
+	prose := markdown.ProseLines(content)
+	known := spelling.NewChecker(spelling.English()).Known
+	changed := false
+
+	for _, h := range findHeadingTexts(lines, prose) {
+		line := lines[h.line]
+		want, _ := r.recase(line[h.start:h.end], prose[h.line][h.start:h.end], known)
+		if want != line[h.start:h.end] {
+			lines[h.line] = line[:h.start] + want + line[h.end:]
+			changed = true
+		}
+	}
+	return FixResult{Changed: changed, Lines: lines}
+}
+
+func (r *MD076) style() string {
+	switch r.Style {
+	case "title", "preserve":
+		return r.Style
+	}
+	return "sentence"
+}
+
+// findHeadingTexts returns the text ranges of ATX headings and single-line
+// setext headings. prose is lines with code and front matter blanked.
+func findHeadingTexts(lines, prose []string) []headingText {
+	var headings []headingText
+	for i, line := range prose {
+		if headingCaseATXRegex.MatchString(line) {
+			// Match the original line: blanked inline code would otherwise
+			// be taken for the space after the hashes.
+			loc := headingCaseATXRegex.FindStringIndex(lines[i])
+			end := len(strings.TrimRight(line, " \t"))
+			if m := headingCaseClosing.FindStringIndex(line[loc[1]:]); m != nil {
+				end = loc[1] + m[0]
+			}
+			if end > loc[1] {
+				headings = append(headings, headingText{i, loc[1], end})
+			}
+			continue
+		}
+
+		trimmed := strings.TrimSpace(line)
+		if trimmed == "" || i+1 >= len(prose) || !headingCaseSetextRegex.MatchString(prose[i+1]) ||
+			(i > 0 && strings.TrimSpace(prose[i-1]) != "") || strings.ContainsAny(trimmed[:1], "-*+>|") ||
+			headingCaseOrderedRegex.MatchString(trimmed) {
+			continue
+		}
+		start := len(line) - len(strings.TrimLeft(line, " "))
+		if start > 3 {
+			continue
+		}
+		headings = append(headings, headingText{i, start, len(strings.TrimRight(line, " \t"))})
+	}
+	return headings
+}
+
+type caseWord struct {
+	start, end int
+	// first is set for the first word of the heading, boundary for the first
+	// word after a colon or dash, last for the last word.
+	first, boundary, last bool
+}
+
+// recase returns text in the configured case. masked is text with inline
+// code, HTML and link destinations blanked, so only prose words change.
+// known reports whether a word is English; in sentence case, capitalised
+// words that are not are taken to be proper nouns. review lists the
+// capitalised English words sentence case leaves alone because they may
+// be names.
+func (r *MD076) recase(text, masked string, known func(string) bool) (recased string, review []string) {
+	out := []byte(text)
+	protected := r.nameRanges(masked)
+	words := caseWords(text, masked)
+
+	for _, w := range words {
+		word := text[w.start:w.end]
+		if overlapsRange(w.start, w.end, protected) {
+			continue
+		}
+		offset := w.start
+		for n, part := range strings.Split(word, "-") {
+			want, unsure := r.recaseWord(part, w, n, known)
+			if unsure {
+				review = append(review, part)
+			}
+			copy(out[offset:], want)
+			offset += len(part) + 1
+		}
+	}
+	for _, p := range protected {
+		copy(out[p.start:], p.name)
+	}
+	return string(out), review
+}
+
+// recaseWord returns one hyphen-separated part of a heading word in the
+// configured case, and whether it is a capitalised English word that
+// sentence case leaves for review.
+func (r *MD076) recaseWord(word string, w caseWord, part int, known func(string) bool) (string, bool) {
+	if !isPlainWord(word) {
+		return word, false
+	}
+	switch r.style() {
+	case "preserve":
+		if w.first && part == 0 && known(word) {
+			return capitalize(word), false
+		}
+	case "title":
+		if part == 0 && (w.first || w.boundary || w.last) {
+			return capitalize(word), false
+		}
+		if r.isSmallWord(word) {
+			return lowercase(word), false
+		}
+		return capitalize(word), false
+	default:
+		// A lowercase first word that is not English, such as npm, is
+		// taken to be a name.
+		lower := lowercase(word)
+		switch {
+		case w.first && part == 0 && (known(word) || word != lower):
+			return capitalize(word), false
+		case w.first && part == 0, w.boundary && part == 0, word == lower:
+			return word, false
+		case lowercaseWords[strings.ToLower(word)]:
+			return lower, false
+		case known(word):
+			return word, true
+		}
+	}
+	return word, false
+}
+
+// quoteWords formats words as a quoted, comma-separated list.
+func quoteWords(words []string) string {
+	quoted := make([]string, len(words))
+	for i, w := range words {
+		quoted[i] = "\"" + w + "\""
+	}
+	return strings.Join(quoted, ", ")
+}
+
+// caseWords splits the prose of a heading into words trimmed of
+// punctuation. Numbers and symbols are not words, so the first word may
+// follow an emoji or "1."; a heading that starts with inline code has no
+// first word.
+func caseWords(text, masked string) []caseWord {
+	var words []caseWord
+	boundary := false
+	for _, f := range fieldIndexes(masked) {
+		token := masked[f[0]:f[1]]
+		start, end := f[0], f[1]
+		for start < end && !isWordRune(masked[start:end], true) {
+			_, size := utf8.DecodeRuneInString(masked[start:end])
+			start += size
+		}
+		for end > start && !isWordRune(masked[start:end], false) {
+			_, size := utf8.DecodeLastRuneInString(masked[start:end])
+			end -= size
+		}
+		if strings.IndexFunc(masked[start:end], unicode.IsLetter) >= 0 {
+			first := len(words) == 0 && text[:start] == masked[:start]
+			words = append(words, caseWord{start: start, end: end, first: first, boundary: boundary})
+		}
+		boundary = token == "-" || token == "–" || token == "—" ||
+			strings.HasSuffix(token, ":") || strings.HasSuffix(token, "?") || strings.HasSuffix(token, "!")
+	}
+	if len(words) > 0 {
+		words[len(words)-1].last = true
+	}
+	return words
+}
+
+func fieldIndexes(s string) [][2]int {
+	var out [][2]int
+	start := -1
+	for i, c := range s {
+		if unicode.IsSpace(c) {
+			if start >= 0 {
+				out = append(out, [2]int{start, i})
+				start = -1
+			}
+		} else if start < 0 {
+			start = i
+		}
+	}
+	if start >= 0 {
+		out = append(out, [2]int{start, len(s)})
+	}
+	return out
+}
+
+func isWordRune(s string, fromStart bool) bool {
+	var r rune
+	if fromStart {
+		r, _ = utf8.DecodeRuneInString(s)
+	} else {
+		r, _ = utf8.DecodeLastRuneInString(s)
+	}
+	return unicode.IsLetter(r) || unicode.IsDigit(r)
+}
+
+// isPlainWord reports whether word is all lowercase or capitalised letters
+// and apostrophes. Acronyms, mixed-case names, numbers and identifiers keep
+// their casing, as does the pronoun I.
+func isPlainWord(word string) bool {
+	if word == "" || word == "I" || strings.HasPrefix(word, "I'") || strings.HasPrefix(word, "I’") {
+		return false
+	}
+	for i, c := range word {
+		switch {
+		case c == '\'' || c == '’':
+		case !unicode.IsLetter(c):
+			return false
+		case unicode.IsUpper(c) && i > 0:
+			return false
+		}
+	}
+	return true
+}
+
+func (r *MD076) isSmallWord(word string) bool {
+	small := r.SmallWords
+	if small == nil {
+		small = DefaultSmallWords
+	}
+	for _, s := range small {
+		if strings.EqualFold(s, word) {
+			return true
+		}
+	}
+	return false
+}
+
+func capitalize(word string) string {
+	c, size := utf8.DecodeRuneInString(word)
+	return string(unicode.ToUpper(c)) + word[size:]
+}
+
+func lowercase(word string) string {
+	c, size := utf8.DecodeRuneInString(word)
+	return string(unicode.ToLower(c)) + word[size:]
+}
+
+type nameRange struct {
+	start, end int
+	name       string
+}
+
+// nameRanges finds the configured names in masked, matched as whole words
+// regardless of case.
+func (r *MD076) nameRanges(masked string) []nameRange {
+	var ranges []nameRange
+	for _, name := range r.Names {
+		name = strings.TrimSpace(name)
+		if name == "" {
+			continue
+		}
+		re, err := regexp.Compile(`(?i)(?:^|[^\pL\pN])(` + regexp.QuoteMeta(name) + `)(?:$|[^\pL\pN])`)
+		if err != nil {
+			continue
+		}
+		for _, m := range re.FindAllStringSubmatchIndex(masked, -1) {
+			if len(masked[m[2]:m[3]]) == len(name) && !overlapsRange(m[2], m[3], ranges) {
+				ranges = append(ranges, nameRange{m[2], m[3], name})
+			}
+		}
+	}
+	return ranges
+}
+
+func overlapsRange(start, end int, ranges []nameRange) bool {
+	for _, r := range ranges {
+		if start < r.end && end > r.start {
+			return true
+		}
+	}
+	return false
+}
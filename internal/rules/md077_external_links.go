@@ -0,0 +1,206 @@
+package rules
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/mohitmishra786/mdmend/internal/config"
+	"github.com/mohitmishra786/mdmend/internal/linkcheck"
+	"github.com/mohitmishra786/mdmend/internal/markdown"
+)
+
+// MD077 reports external links that do not resolve. It makes network
+// requests, so it only runs when enabled and given a Checker; Configure
+// shares one Checker, and with it the result cache and per-host limits,
+// between all files with the same options, or records why the options are
+// invalid in OptionsErr, which Lint reports.
+type MD077 struct {
+	Enabled    bool
+	Checker    *linkcheck.Checker
+	OptionsErr error
+}
+
+func init() {
+	Register(&MD077{Enabled: false})
+}
+
+func (r *MD077) ID() string          { return "MD077" }
+func (r *MD077) Name() string        { return "external-links" }
+func (r *MD077) Description() string { return "External links should resolve" }
+func (r *MD077) Fixable() bool       { return false }
+
+func (r *MD077) Lint(content string, path string) []Violation {
+	if r.Enabled && r.OptionsErr != nil {
+		return []Violation{{
+			Rule:    r.ID(),
+			Line:    1,
+			Column:  1,
+			Message: "Invalid options: " + r.OptionsErr.Error(),
+		}}
+	}
+	if !r.Enabled || r.Checker == nil {
+		return nil
+	}
+
+	links := ExternalLinks(content)
+	urls := make([]string, len(links))
+	for i, l := range links {
+		urls[i] = l.URL
+	}
+	failed := make(map[string]linkcheck.Result)
+	for _, res := range r.Checker.Check(context.Background(), urls) {
+		if !res.OK() {
+			failed[res.URL] = res
+		}
+	}
+
+	var violations []Violation
+	for _, l := range links {
+		if res, ok := failed[l.URL]; ok {
+			violations = append(violations, Violation{
+				Rule:    r.ID(),
+				Line:    l.Line,
+				Column:  l.Column,
+				Message: fmt.Sprintf("Dead link (%s): %s", res.Reason(), l.URL),
+				Fixable: false,
+			})
+		}
+	}
+	return violations
+}
+
+func (r *MD077) Fix(content string, path string) FixResult {
+	return FixResult{Changed: false, Lines: strings.Split(content, "\n")}
+}
+
+// ExternalLink is an http or https URL in a document.
+type ExternalLink struct {
+	Line   int
+	Column int
+	URL    string
+}
+
+var externalURLRegex = regexp.MustCompile(`https?://[^\s<>"'\x60\[\]]+`)
+
+// ExternalLinks returns the http and https URLs of inline links and images,
+// reference definitions, HTML href and src attributes, autolinks and bare
+// URLs, outside code and HTML comments.
+func ExternalLinks(content string) []ExternalLink {
+	var links []ExternalLink
+	lines := markdown.MarkupLines(content)
+	claimed := make([][]markdown.Span, len(lines))
+
+	for _, t := range findLinkTargets(content) {
+		dest := lines[t.line][t.start:t.end]
+		claimed[t.line] = append(claimed[t.line], markdown.Span{Start: t.start, End: t.end})
+		if isExternalURL(dest) {
+			links = append(links, ExternalLink{Line: t.line + 1, Column: t.start + 1, URL: dest})
+		}
+	}
+	for i, line := range lines {
+		for _, loc := range externalURLRegex.FindAllStringIndex(line, -1) {
+			if markdown.OverlapsSpan(loc[0], loc[1], claimed[i]) {
+				continue
+			}
+			u := strings.TrimRight(line[loc[0]:loc[1]], ".,;:!?*_~")
+			if strings.HasSuffix(u, ")") && strings.Count(u, "(") < strings.Count(u, ")") {
+				u = u[:len(u)-1]
+			}
+			links = append(links, ExternalLink{Line: i + 1, Column: loc[0] + 1, URL: u})
+		}
+	}
+	sort.SliceStable(links, func(a, b int) bool {
+		if links[a].Line != links[b].Line {
+			return links[a].Line < links[b].Line
+		}
+		return links[a].Column < links[b].Column
+	})
+	return links
+}
+
+func isExternalURL(s string) bool {
+	lower := strings.ToLower(s)
+	return strings.HasPrefix(lower, "http://") || strings.HasPrefix(lower, "https://")
+}
+
+// ExternalLinkOptions reads the checker options in rc. Durations use Go
+// syntax such as 10s; retries is -1 to disable retrying.
+func ExternalLinkOptions(rc config.RuleConfig) (linkcheck.Options, error) {
+	opts := linkcheck.Options{
+		Timeout:      linkcheck.DefaultTimeout,
+		Concurrency:  rc.Concurrency,
+		HostInterval: linkcheck.DefaultHostInterval,
+		Retries:      linkcheck.DefaultRetries,
+		Allow:        rc.Allow,
+		Deny:         rc.Deny,
+	}
+	if rc.Retries != 0 {
+		opts.Retries = rc.Retries
+	}
+	for _, d := range []struct {
+		key   string
+		value string
+		dst   *time.Duration
+	}{
+		{"timeout", rc.Timeout, &opts.Timeout},
+		{"host_interval", rc.HostInterval, &opts.HostInterval},
+	} {
+		if d.value == "" {
+			continue
+		}
+		v, err := time.ParseDuration(d.value)
+		if err != nil {
+			return opts, fmt.Errorf("MD077 %s: %w", d.key, err)
+		}
+		*d.dst = v
+	}
+	return opts, nil
+}
+
+// ExternalLinkCacheTTL reads the cache_ttl option in rc.
+func ExternalLinkCacheTTL(rc config.RuleConfig) (time.Duration, error) {
+	if rc.CacheTTL == "" {
+		return linkcheck.DefaultCacheTTL, nil
+	}
+	ttl, err := time.ParseDuration(rc.CacheTTL)
+	if err != nil {
+		return 0, fmt.Errorf("MD077 cache_ttl: %w", err)
+	}
+	return ttl, nil
+}
+
+var (
+	externalCheckersMu sync.Mutex
+	externalCheckers   = make(map[string]*linkcheck.Checker)
+)
+
+// externalChecker returns the Checker shared by every file configured with
+// the options in rc.
+func externalChecker(rc config.RuleConfig) (*linkcheck.Checker, error) {
+	key := fmt.Sprintf("%q %q %s %s %s %d %d", rc.Allow, rc.Deny, rc.Timeout, rc.HostInterval, rc.CacheTTL, rc.Concurrency, rc.Retries)
+	externalCheckersMu.Lock()
+	defer externalCheckersMu.Unlock()
+	if c, ok := externalCheckers[key]; ok {
+		return c, nil
+	}
+
+	opts, err := ExternalLinkOptions(rc)
+	if err != nil {
+		return nil, err
+	}
+	ttl, err := ExternalLinkCacheTTL(rc)
+	if err != nil {
+		return nil, err
+	}
+	if cache, err := linkcheck.LoadCache("", ttl); err == nil {
+		opts.Cache = cache
+	}
+	c := linkcheck.New(opts)
+	externalCheckers[key] = c
+	return c, nil
+}
@@ -0,0 +1,156 @@
+package rules
+
+import (
+	"sort"
+	"strings"
+
+	"github.com/mohitmishra786/mdmend/internal/config"
+)
+
+const (
+	TagHeadings      = "headings"
+	TagLists         = "lists"
+	TagWhitespace    = "whitespace"
+	TagLinks         = "links"
+	TagCode          = "code"
+	TagTables        = "tables"
+	TagAccessibility = "accessibility"
+	TagHeuristic     = "heuristic"
+	TagEmphasis      = "emphasis"
+	TagBlockquote    = "blockquote"
+	TagHTML          = "html"
+	TagImages        = "images"
+	TagFootnotes     = "footnotes"
+	TagSpelling      = "spelling"
+	TagHR            = "hr"
+)
+
+const (
+	markdownlintDocURL = "https://github.com/DavidAnson/markdownlint/blob/main/doc/"
+	mdmendRulesDocURL  = "https://github.com/mohitmishra786/mdmend/blob/main/RULES.md"
+)
+
+var ruleTags = map[string][]string{
+	"MD001": {TagHeadings},
+	"MD003": {TagHeadings},
+	"MD004": {TagLists},
+	"MD005": {TagLists, TagWhitespace},
+	"MD007": {TagLists, TagWhitespace},
+	"MD009": {TagWhitespace},
+	"MD010": {TagWhitespace},
+	"MD011": {TagLinks},
+	"MD012": {TagWhitespace},
+	"MD014": {TagCode},
+	"MD018": {TagHeadings, TagWhitespace},
+	"MD019": {TagHeadings, TagWhitespace},
+	"MD020": {TagHeadings, TagWhitespace},
+	"MD021": {TagHeadings, TagWhitespace},
+	"MD022": {TagHeadings, TagWhitespace},
+	"MD023": {TagHeadings, TagWhitespace},
+	"MD024": {TagHeadings},
+	"MD025": {TagHeadings},
+	"MD026": {TagHeadings},
+	"MD027": {TagBlockquote, TagWhitespace},
+	"MD028": {TagBlockquote, TagWhitespace},
+	"MD029": {TagLists},
+	"MD030": {TagLists, TagWhitespace},
+	"MD031": {TagCode, TagWhitespace},
+	"MD032": {TagLists, TagWhitespace},
+	"MD033": {TagHTML},
+	"MD034": {TagLinks, TagHeuristic},
+	"MD035": {TagHR},
+	"MD036": {TagHeadings, TagEmphasis},
+	"MD037": {TagEmphasis, TagWhitespace},
+	"MD038": {TagCode, TagWhitespace},
+	"MD039": {TagLinks, TagWhitespace},
+	"MD040": {TagCode, TagHeuristic},
+	"MD041": {TagHeadings},
+	"MD042": {TagLinks},
+	"MD043": {TagHeadings},
+	"MD044": {TagSpelling},
+	"MD045": {TagImages, TagAccessibility},
+	"MD046": {TagCode},
+	"MD047": {TagWhitespace},
+	"MD048": {TagCode},
+	"MD049": {TagEmphasis},
+	"MD050": {TagEmphasis},
+	"MD051": {TagLinks},
+	"MD052": {TagLinks, TagImages},
+	"MD053": {TagLinks, TagImages},
+	"MD054": {TagLinks, TagImages},
+	"MD055": {TagTables},
+	"MD056": {TagTables},
+	"MD057": {TagLinks, TagImages},
+	"MD058": {TagTables, TagWhitespace},
+	"MD059": {TagLinks, TagAccessibility},
+	"MD060": {TagTables},
+	"MD066": {TagFootnotes},
+	"MD067": {TagFootnotes},
+	"MD068": {TagFootnotes},
+	"MD070": {TagCode},
+	"MD073": {TagHeadings, TagLinks},
+	"MD074": {TagSpelling},
+	"MD075": {TagSpelling},
+	"MD076": {TagHeadings},
+	"MD077": {TagLinks},
+}
+
+func init() {
+	config.SetRuleLabels(Labels)
+}
+
+func Tags(id string) []string {
+	return ruleTags[id]
+}
+
+// Aliases returns the alternative names a rule can be selected by.
+func Aliases(id string) []string {
+	if r := Get(id); r != nil && r.Name() != "" {
+		return []string{r.Name()}
+	}
+	return nil
+}
+
+// Labels returns every alias and tag of a rule, for selector matching.
+func Labels(id string) []string {
+	return append(Aliases(id), Tags(id)...)
+}
+
+func DocURL(id string) string {
+	if config.IsMarkdownlintRule(id) {
+		return markdownlintDocURL + strings.ToLower(id) + ".md"
+	}
+	if Get(id) != nil {
+		return mdmendRulesDocURL
+	}
+	return ""
+}
+
+// AllTags returns every tag used by a registered rule, sorted.
+func AllTags() []string {
+	seen := map[string]bool{}
+	for _, id := range IDs() {
+		for _, tag := range Tags(id) {
+			seen[tag] = true
+		}
+	}
+	tags := make([]string, 0, len(seen))
+	for tag := range seen {
+		tags = append(tags, tag)
+	}
+	sort.Strings(tags)
+	return tags
+}
+
+// Select returns the IDs of registered rules matched by selector, which may
+// be a rule ID, an alias or a tag.
+func Select(selector string) []string {
+	var ids []string
+	for _, id := range IDs() {
+		if config.MatchesRule(selector, id) {
+			ids = append(ids, id)
+		}
+	}
+	sort.Strings(ids)
+	return ids
+}
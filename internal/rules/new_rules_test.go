@@ -1,10 +1,15 @@
 package rules
 
 import (
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
 	"strings"
 	"testing"
 
 	"github.com/mohitmishra786/mdmend/internal/config"
+	"github.com/mohitmishra786/mdmend/internal/linkcheck"
 )
 
 func TestMD046Fix(t *testing.T) {
@@ -284,6 +289,95 @@ func TestMD073(t *testing.T) {
 			t.Fatalf("expected rebuilt toc entry, got %q", result.Content())
 		}
 	})
+
+	doc := "# Title\n\n## A\n\n#### Deep\n\n### B\n\n## C\n"
+
+	t.Run("generate nested list", func(t *testing.T) {
+		tests := []struct {
+			rule *MD073
+			want []string
+		}{
+			{rule, []string{"- [A](#a)", "  - [Deep](#deep)", "  - [B](#b)", "- [C](#c)"}},
+			{&MD073{Style: "asterisk", Indent: 4, MaxLevel: 3}, []string{"* [A](#a)", "    * [B](#b)", "* [C](#c)"}},
+			{&MD073{Style: "ordered", MaxLevel: 4}, []string{"1. [A](#a)", "   1. [Deep](#deep)", "   1. [B](#b)", "2. [C](#c)"}},
+		}
+		for _, tt := range tests {
+			if got := tt.rule.Generate(doc); strings.Join(got, "\n") != strings.Join(tt.want, "\n") {
+				t.Errorf("Generate() with %s = %q, want %q", tt.rule.Style, got, tt.want)
+			}
+		}
+	})
+
+	t.Run("formatting", func(t *testing.T) {
+		input := "# Title\n<!-- toc -->\n* [Section One](#section-one)\n<!-- /toc -->\n\n## Section One\n"
+		if v := rule.Lint(input, "test.md"); len(v) != 0 {
+			t.Errorf("formatting reported without CheckFormat: %+v", v)
+		}
+		if rule.Fix(input, "test.md").Changed {
+			t.Error("fix reformatted a correct TOC without CheckFormat")
+		}
+
+		check := *rule
+		check.CheckFormat = true
+		v := check.Lint(input, "test.md")
+		if len(v) != 1 || v[0].Line != 3 {
+			t.Fatalf("got %+v, want one formatting violation on line 3", v)
+		}
+		if got := check.Fix(input, "test.md").Content(); !strings.Contains(got, "\n- [Section One](#section-one)\n") {
+			t.Errorf("fix = %q", got)
+		}
+	})
+
+	t.Run("doctoc markers keep title", func(t *testing.T) {
+		input := strings.Join([]string{
+			"# Title",
+			"<!-- START doctoc generated TOC please keep comment here to allow auto update -->",
+			"**Table of Contents**",
+			"",
+			"- [Old](#old)",
+			"",
+			"<!-- END doctoc generated TOC please keep comment here to allow auto update -->",
+			"",
+			"## New",
+		}, "\n")
+		want := strings.Replace(input, "[Old](#old)", "[New](#new)", 1)
+		if got := rule.Fix(input, "test.md").Content(); got != want {
+			t.Errorf("fix = %q, want %q", got, want)
+		}
+	})
+
+	t.Run("insert after first heading", func(t *testing.T) {
+		insert := &MD073{Enabled: true, MinLevel: 2, MaxLevel: 4, EnforceOrder: true, Style: "dash", Indent: 2, Insert: true}
+		input := "# Title\nIntro.\n\n## One\n"
+		if v := insert.Lint(input, "test.md"); len(v) != 1 || v[0].Line != 1 {
+			t.Fatalf("got %+v, want a missing TOC violation on line 1", v)
+		}
+		want := "# Title\n\n<!-- toc -->\n\n- [One](#one)\n\n<!-- /toc -->\n\nIntro.\n\n## One\n"
+		if got := insert.Fix(input, "test.md").Content(); got != want {
+			t.Fatalf("fix = %q, want %q", got, want)
+		}
+		if v := insert.Lint(want, "test.md"); len(v) != 0 {
+			t.Errorf("inserted TOC reported: %+v", v)
+		}
+		if rule.Fix(input, "test.md").Changed {
+			t.Error("TOC inserted without Insert")
+		}
+	})
+
+	t.Run("list style follows MD004 and MD007", func(t *testing.T) {
+		cfg := config.Default()
+		cfg.Rules["MD004"] = config.RuleConfig{Style: "plus"}
+		cfg.Rules["MD007"] = config.RuleConfig{Indent: 4}
+		got := ConfigureFromConfig(&MD073{}, cfg).(*MD073)
+		if got.Style != "plus" || got.Indent != 4 || got.CheckFormat {
+			t.Errorf("Style, Indent, CheckFormat = %q, %d, %v, want plus, 4, false", got.Style, got.Indent, got.CheckFormat)
+		}
+		cfg.Rules["MD073"] = config.RuleConfig{Style: "ordered", MaxLevel: 6}
+		got = ConfigureFromConfig(&MD073{}, cfg).(*MD073)
+		if got.Style != "ordered" || got.MaxLevel != 6 || !got.CheckFormat {
+			t.Errorf("Style, MaxLevel, CheckFormat = %q, %d, %v, want ordered, 6, true", got.Style, got.MaxLevel, got.CheckFormat)
+		}
+	})
 }
 
 func TestConfigureFromConfig(t *testing.T) {
@@ -313,3 +407,582 @@ func TestConfigureFromConfig(t *testing.T) {
 func boolPtr(v bool) *bool {
 	return &v
 }
+
+func TestMigrationReport(t *testing.T) {
+	cfg := config.Default()
+	notes := MigrationReport(cfg)
+
+	byRule := map[string]MigrationNote{}
+	for _, n := range notes {
+		byRule[n.Rule] = n
+	}
+
+	if n, ok := byRule["MD044"]; !ok || n.Compat != CompatDifferent || !n.Enabled {
+		t.Errorf("MD044 note = %+v, want enabled different", n)
+	}
+	if n, ok := byRule["MD073"]; !ok || n.Compat != CompatMdmendOnly || n.Enabled {
+		t.Errorf("MD073 note = %+v, want disabled mdmend-only", n)
+	}
+	if _, ok := byRule["MD001"]; ok {
+		t.Error("MD001 behaves the same and should not be reported")
+	}
+	for i := 1; i < len(notes); i++ {
+		if notes[i-1].Rule > notes[i].Rule {
+			t.Fatalf("notes not sorted: %s before %s", notes[i-1].Rule, notes[i].Rule)
+		}
+	}
+}
+
+func TestMarkdownlintCompat(t *testing.T) {
+	if info := MarkdownlintCompat("MD001"); info.Compat != CompatSame || info.Name != "heading-increment" {
+		t.Errorf("MD001 = %+v, want same heading-increment", info)
+	}
+	if info := MarkdownlintCompat("MD059"); info.Compat != CompatDifferent || info.Name != "descriptive-link-text" {
+		t.Errorf("MD059 = %+v, want different descriptive-link-text", info)
+	}
+	if info := MarkdownlintCompat("MD060"); info.Compat != CompatDifferent || info.Name != "table-column-style" {
+		t.Errorf("MD060 = %+v, want different table-column-style", info)
+	}
+}
+
+func TestRuleMetadata(t *testing.T) {
+	for _, id := range IDs() {
+		if len(Aliases(id)) == 0 {
+			t.Errorf("%s has no alias", id)
+		}
+		if DocURL(id) == "" {
+			t.Errorf("%s has no documentation URL", id)
+		}
+		for _, tag := range Tags(id) {
+			if tag != strings.ToLower(tag) {
+				t.Errorf("%s tag %q should be lower-case", id, tag)
+			}
+		}
+	}
+
+	links := Select("links")
+	if len(links) == 0 || !containsString(links, "MD034") || !containsString(links, "MD057") {
+		t.Errorf("Select(links) = %v", links)
+	}
+	if got := Select("no-bare-urls"); len(got) != 1 || got[0] != "MD034" {
+		t.Errorf("Select(no-bare-urls) = %v, want [MD034]", got)
+	}
+
+	cfg := &config.Config{Only: []string{"LINKS", "~MD057"}}
+	enabled := EnabledRules(cfg, false)
+	for _, r := range enabled {
+		if r.ID() == "MD057" || !containsString(Tags(r.ID()), TagLinks) {
+			t.Errorf("only=links,~MD057 enabled %s", r.ID())
+		}
+	}
+	if len(enabled) != len(links)-1 {
+		t.Errorf("got %d enabled rules, want %d", len(enabled), len(links)-1)
+	}
+}
+
+func TestCanFix(t *testing.T) {
+	md009, md026 := Get("MD009"), Get("MD026")
+	if FixLevel("MD009") != config.FixLevelSafe || FixLevel("MD040") != config.FixLevelUnsafe {
+		t.Errorf("FixLevel(MD009) = %s, FixLevel(MD040) = %s", FixLevel("MD009"), FixLevel("MD040"))
+	}
+	for _, id := range IDs() {
+		if r := Get(id); !r.Fixable() && safeFixes[id] {
+			t.Errorf("%s is listed as a safe fix but is not fixable", id)
+		}
+	}
+
+	cfg := config.Default()
+	if !CanFix(cfg, md009) || !CanFix(cfg, md026) {
+		t.Error("default fix level should allow every fixable rule")
+	}
+	if CanFix(cfg, Get("MD001")) {
+		t.Error("MD001 is not fixable")
+	}
+
+	cfg.FixLevel = config.FixLevelSafe
+	if !CanFix(cfg, md009) || CanFix(cfg, md026) {
+		t.Error("fix_level safe should allow MD009 but not MD026")
+	}
+
+	cfg = config.Default()
+	off := false
+	cfg.Rules["MD009"] = config.RuleConfig{Fix: &off}
+	if CanFix(cfg, md009) {
+		t.Error("fix: false should disable MD009 fixes")
+	}
+}
+
+func containsString(items []string, target string) bool {
+	for _, item := range items {
+		if item == target {
+			return true
+		}
+	}
+	return false
+}
+
+func TestMD074(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "terms.yml")
+	vocab := `terms:
+  - term: GitHub
+    variants: [Github]
+  - term: sign in
+    patterns: ['\blog ?in\b']
+    severity: warning
+  - term: Kubernetes
+    allowed_in_code: false
+`
+	if err := os.WriteFile(path, []byte(vocab), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	rule := &MD074{Vocabulary: path}
+
+	input := "Push to Github, then log in.\n\n`Github` and `kubernetes`\n"
+	violations := rule.Lint(input, "test.md")
+	if len(violations) != 3 {
+		t.Fatalf("got %d violations, want 3: %+v", len(violations), violations)
+	}
+	if v := violations[0]; v.Column != 9 || !v.Fixable || v.Suggested != "GitHub" {
+		t.Errorf("variant violation = %+v", v)
+	}
+	if v := violations[1]; v.Fixable || v.Severity != SeverityWarning {
+		t.Errorf("pattern violation = %+v, want unfixable warning", v)
+	}
+	if v := violations[2]; v.Line != 3 || v.Fixable {
+		t.Errorf("code violation = %+v, want unfixable on line 3", v)
+	}
+
+	want := "Push to GitHub, then log in.\n\n`Github` and `kubernetes`\n"
+	if got := rule.Fix(input, "test.md").Content(); got != want {
+		t.Errorf("Fix() = %q, want %q", got, want)
+	}
+
+	missing := &MD074{Vocabulary: filepath.Join(t.TempDir(), "missing.yml")}
+	if v := missing.Lint(input, "test.md"); len(v) != 1 || v[0].Fixable {
+		t.Errorf("missing vocabulary = %+v, want one unfixable violation", v)
+	}
+	if v := (&MD074{}).Lint(input, "test.md"); len(v) != 0 {
+		t.Errorf("no vocabulary reported %+v", v)
+	}
+}
+
+func TestMD044ProseOnly(t *testing.T) {
+	rule := &MD044{Names: []string{"GitHub"}}
+	input := "See `github` at https://github.com/x and [github](https://github.com).\n\n```\ngithub\n```\n"
+	violations := rule.Lint(input, "test.md")
+	if len(violations) != 1 || violations[0].Column != 43 {
+		t.Fatalf("violations = %+v, want only the link text", violations)
+	}
+	want := "See `github` at https://github.com/x and [GitHub](https://github.com).\n\n```\ngithub\n```\n"
+	if got := rule.Fix(input, "test.md").Content(); got != want {
+		t.Errorf("Fix() = %q, want %q", got, want)
+	}
+
+	rule.CodeBlocks = true
+	violations = rule.Lint(input, "test.md")
+	if len(violations) != 3 {
+		t.Fatalf("code_blocks: got %d violations, want 3", len(violations))
+	}
+	for _, v := range violations {
+		if v.Fixable != (v.Column == 43) {
+			t.Errorf("only the prose match should be fixable: %+v", v)
+		}
+	}
+}
+
+func TestMD075(t *testing.T) {
+	root := t.TempDir()
+	docs := filepath.Join(root, "docs")
+	if err := os.MkdirAll(docs, 0o755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(root, ".mdmend-words.txt"), []byte("# project\nmdmend\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(docs, ".mdmend-words.txt"), []byte("frobnicate\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	rule := &MD075{Words: []string{"Kubernetes"}}
+	input := "Use mdmend to frobnicate Kubernetes docs.\n\nThis is teh `recieve` example.\n"
+
+	violations := rule.Lint(input, filepath.Join(docs, "guide.md"))
+	if len(violations) != 1 {
+		t.Fatalf("got %d violations, want 1: %+v", len(violations), violations)
+	}
+	v := violations[0]
+	if v.Line != 3 || v.Column != 9 || v.EndColumn != 12 || v.Suggested != "the" || v.Fixable {
+		t.Errorf("violation = %+v", v)
+	}
+
+	violations = rule.Lint(input, filepath.Join(root, "README.md"))
+	if len(violations) != 2 || violations[0].Message != `Unknown word "frobnicate"` {
+		t.Errorf("outside docs/ got %+v, want frobnicate and teh", violations)
+	}
+
+	first, _ := rule.checker(filepath.Join(docs, "guide.md"))
+	again, _ := (&MD075{Words: []string{"Kubernetes"}}).checker(filepath.Join(docs, "other.md"))
+	if first != again {
+		t.Error("the same words and dictionaries should share a checker")
+	}
+	if other, _ := rule.checker(filepath.Join(root, "README.md")); other == first {
+		t.Error("a different stack of word files should get its own checker")
+	}
+
+	missing := &MD075{Dictionaries: []string{filepath.Join(root, "missing.dic")}}
+	if v := missing.Lint(input, "test.md"); len(v) != 1 || v[0].Fixable {
+		t.Errorf("missing dictionary = %+v, want one unfixable violation", v)
+	}
+}
+
+func TestMD059(t *testing.T) {
+	input := strings.Join([]string{
+		"For details [click here](https://a.example).",
+		"See the [guide][ref], [**More!**](x.md) and [Read more][].",
+		"Visit [https://a.example](https://a.example).",
+		"[![badge](img.svg)](https://ci.example) and `[here](code)`",
+		"The [Guide](https://b.example) moved.",
+		"",
+		"[ref]: https://a.example/guide/",
+		"[read more]: more.md",
+	}, "\n")
+
+	violations := (&MD059{}).Lint(input, "test.md")
+	want := []struct{ line, column int }{{1, 13}, {2, 23}, {2, 45}, {3, 7}, {5, 5}}
+	if len(violations) != len(want) {
+		t.Fatalf("got %d violations, want %d: %+v", len(violations), len(want), violations)
+	}
+	for i, w := range want {
+		if violations[i].Line != w.line || violations[i].Column != w.column {
+			t.Errorf("violation %d at %d:%d, want %d:%d (%s)", i, violations[i].Line, violations[i].Column, w.line, w.column, violations[i].Message)
+		}
+	}
+	if !strings.Contains(violations[4].Message, "line 2") {
+		t.Errorf("duplicate text message = %q", violations[4].Message)
+	}
+
+	german := &MD059{Languages: []string{"de"}}
+	if v := german.Lint("Mehr [hier](a.md) und [click here](b.md).\n", "test.md"); len(v) != 1 || v[0].Column != 6 {
+		t.Errorf("de pack = %+v, want only hier", v)
+	}
+	custom := &MD059{ProhibitedTexts: []string{"docs"}}
+	if v := custom.Lint("[Docs](a.md) and [here](b.md)\n", "test.md"); len(v) != 1 || v[0].Column != 1 {
+		t.Errorf("prohibited_texts = %+v, want only docs", v)
+	}
+}
+
+func TestMD060(t *testing.T) {
+	input := strings.Join([]string{
+		"| Name | 説明 | Size |",
+		"|:-|:---:|--:|",
+		"| `a\\|b` | 日本 | 10 |",
+		"| c \\| d | x | 2 |",
+	}, "\n")
+
+	aligned := &MD060{Style: "aligned"}
+	if v := aligned.Lint(input, "test.md"); len(v) != 4 {
+		t.Errorf("aligned lint = %+v, want 4 violations", v)
+	}
+	got := strings.Join(aligned.Fix(input, "test.md").Lines, "\n")
+	want := strings.Join([]string{
+		"| Name   | 説明  | Size |",
+		"| :----- | :---: | ---: |",
+		"| `a\\|b` | 日本  |   10 |",
+		"| c \\| d |   x   |    2 |",
+	}, "\n")
+	if got != want {
+		t.Errorf("aligned fix:\n%s\nwant:\n%s", got, want)
+	}
+	if v := aligned.Lint(got, "test.md"); len(v) != 0 {
+		t.Errorf("aligned table still has violations: %+v", v)
+	}
+
+	compact := &MD060{Style: "compact"}
+	got = strings.Join(compact.Fix(want, "test.md").Lines, "\n")
+	if !strings.HasPrefix(got, "| Name | 説明 | Size |\n| :--- | :---: | ---: |\n| `a\\|b` | 日本 | 10 |") {
+		t.Errorf("compact fix:\n%s", got)
+	}
+	v := compact.Lint("| a | b |\n| :---- | --- |\n| c | d |", "test.md")
+	if len(v) != 1 || v[0].Line != 2 || !strings.Contains(v[0].Message, "delimiter") {
+		t.Errorf("compact delimiter = %+v, want one delimiter violation", v)
+	}
+
+	tight := &MD060{Style: "tight"}
+	got = strings.Join(tight.Fix("a | b\n--- | ---\nc | d", "test.md").Lines, "\n")
+	if got != "a|b\n---|---\nc|d" {
+		t.Errorf("tight fix without outer pipes = %q", got)
+	}
+
+	anyStyle := &MD060{Style: "any"}
+	for _, ok := range []string{want, "| a | b |\n| :-- | - |\n| cc | d |", "|a|b|\n|-|-|\n|c|d|", "| a | b |\n|---|---|\n| c | d |"} {
+		if v := anyStyle.Lint(ok, "test.md"); len(v) != 0 {
+			t.Errorf("any style reported a consistent table %q: %+v", ok, v)
+		}
+	}
+	mixed := "| a | b |\n| --- | --- |\n|c|d|"
+	if v := anyStyle.Lint(mixed, "test.md"); len(v) != 1 || v[0].Line != 3 {
+		t.Errorf("any style mixed = %+v, want line 3", v)
+	}
+	if got := anyStyle.Fix(mixed, "test.md").Lines[2]; got != "| c | d |" {
+		t.Errorf("any style fix = %q, want header's compact style", got)
+	}
+
+	skipped := "```\n| a | b |\n|-|-|\n```\n\n| a | b |\n|---|---|\n| c |"
+	if v := aligned.Lint(skipped, "test.md"); len(v) != 0 {
+		t.Errorf("code and ragged tables should be skipped: %+v", v)
+	}
+	// An unescaped pipe in inline code splits the cell, as MD056 counts it.
+	if v := aligned.Lint("| a | b |\n|---|---|\n| `x|y` | z |", "test.md"); len(v) != 0 {
+		t.Errorf("pipe in inline code should make the row ragged: %+v", v)
+	}
+}
+
+func TestMD076(t *testing.T) {
+	sentence := &MD076{Style: "sentence", Names: []string{"GitHub", "Visual Studio Code"}}
+	input := strings.Join([]string{
+		"# Getting Started With The API",
+		"",
+		"## Using `MyFunc` In Visual studio code On iOS",
+		"",
+		"## Setup: Install The CLI ##",
+		"",
+		"Working With github",
+		"-------------------",
+		"",
+		"```",
+		"# Not A Heading",
+		"```",
+		"",
+		"## Already fine",
+	}, "\n")
+
+	violations := sentence.Lint(input, "test.md")
+	if len(violations) != 4 {
+		t.Fatalf("got %d violations, want 4: %+v", len(violations), violations)
+	}
+	v := violations[0]
+	if v.Line != 1 || v.Column != 3 || v.EndColumn != 31 || v.Suggested != "Getting started with the API" || !v.Fixable {
+		t.Errorf("violation = %+v", v)
+	}
+
+	want := strings.Join([]string{
+		"# Getting started with the API",
+		"",
+		"## Using `MyFunc` in Visual Studio Code on iOS",
+		"",
+		"## Setup: Install the CLI ##",
+		"",
+		"Working with GitHub",
+		"-------------------",
+	}, "\n")
+	got := strings.Join(sentence.Fix(input, "test.md").Lines, "\n")
+	if !strings.HasPrefix(got, want) {
+		t.Errorf("sentence fix:\n%s\nwant prefix:\n%s", got, want)
+	}
+	if v := sentence.Lint(got, "test.md"); len(v) != 0 {
+		t.Errorf("fix not idempotent: %+v", v)
+	}
+
+	names := "# Using Go And Python On Linux\n\n## Amazon Web Services In English On Monday\n"
+	violations = sentence.Lint(names, "test.md")
+	if len(violations) != 2 || !strings.Contains(violations[0].Message, `"Go", "Python", "Linux"`) {
+		t.Fatalf("proper nouns: %+v", violations)
+	}
+	want = "# Using Go and Python on Linux\n\n## Amazon Web Services in English on Monday\n"
+	got = strings.Join(sentence.Fix(names, "test.md").Lines, "\n")
+	if got != want {
+		t.Errorf("proper nouns fix = %q, want %q", got, want)
+	}
+	if v := sentence.Lint(got, "test.md"); len(v) != 2 || v[0].Fixable || v[0].Suggested != "" {
+		t.Errorf("capitalised English words should be reported without a fix: %+v", v)
+	}
+
+	title := &MD076{Style: "title"}
+	if got, _ := title.recase("a guide to the built-in tools of Kubernetes", "a guide to the built-in tools of Kubernetes", nil); got != "A Guide to the Built-in Tools of Kubernetes" {
+		t.Errorf("title case = %q", got)
+	}
+	custom := &MD076{Style: "title", SmallWords: []string{"with"}}
+	if got := custom.Fix("# working with the API\n", "test.md").Lines[0]; got != "# Working with The API" {
+		t.Errorf("custom small words = %q", got)
+	}
+
+	preserve := &MD076{Style: "preserve"}
+	if v := preserve.Lint("# Mixed Case headings Are fine\n\n# `code` first\n", "test.md"); len(v) != 0 {
+		t.Errorf("preserve reported %+v", v)
+	}
+	if got := preserve.Fix("# lowercase start\n", "test.md").Lines[0]; got != "# Lowercase start" {
+		t.Errorf("preserve fix = %q", got)
+	}
+}
+
+func TestMD057Targets(t *testing.T) {
+	root := t.TempDir()
+	files := map[string]string{
+		"docs/guide.md":  "# Guide\n\n## Setup\n\n## Install\n",
+		"docs/README.md": "# Overview\n",
+		"img/logo.png":   "",
+	}
+	for name, body := range files {
+		p := filepath.Join(root, filepath.FromSlash(name))
+		if err := os.MkdirAll(filepath.Dir(p), 0o755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(p, []byte(body), 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	input := strings.Join([]string{
+		"# Top",
+		"",
+		"[ok](docs/guide.md#setup) [dir](docs/#overview) [self](README.md#top) ![logo](<img/logo.png>)",
+		"[anchor](docs/guide.md#instal)",
+		"[case](Docs/guide.md)",
+		"![missing](img/logoo.png)",
+		"[dir anchor](docs#nope)",
+		"[badge ![b](img/badge.svg)](docs/guide.md)",
+		`<a href="docs/guide.md#setup">guide</a> <img src="img/nope.png">`,
+		"`[code](nope.md)` [web](https://example.com/x.md) [abs](/nope.md) [here](#top) <!-- [c](nope.md) -->",
+		"",
+		"[ref]: docs/missing.md",
+	}, "\n")
+
+	rule := &MD057{SuggestClosest: true}
+	violations := rule.Lint(input, filepath.Join(root, "README.md"))
+	want := []struct {
+		line      int
+		message   string
+		suggested string
+	}{
+		{4, "Link fragment not found in docs/guide.md: #instal", "install"},
+		{5, "Link target case differs from the file on disk: Docs/guide.md", "docs/guide.md"},
+		{6, "Missing image: img/logoo.png", "img/logo.png"},
+		{7, "Link fragment not found in docs/README.md: #nope", ""},
+		{8, "Missing image: img/badge.svg", ""},
+		{9, "Missing image: img/nope.png", ""},
+		{12, "Broken relative link: docs/missing.md", ""},
+	}
+	if len(violations) != len(want) {
+		t.Fatalf("got %d violations, want %d: %+v", len(violations), len(want), violations)
+	}
+	for i, w := range want {
+		v := violations[i]
+		if v.Line != w.line || v.Message != w.message || v.Suggested != w.suggested {
+			t.Errorf("violation %d = %+v, want line %d %q → %q", i, v, w.line, w.message, w.suggested)
+		}
+	}
+	if v := violations[0]; v.Column != 24 || v.EndColumn != 30 {
+		t.Errorf("fragment range = %d-%d, want 24-30", v.Column, v.EndColumn)
+	}
+	if v := violations[1]; v.Severity != SeverityWarning || v.Column != 8 || v.EndColumn != 21 {
+		t.Errorf("case violation = %+v", v)
+	}
+}
+
+func TestMD077(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/ok" {
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer srv.Close()
+
+	input := strings.Join([]string{
+		"See [ok](" + srv.URL + "/ok \"title\") and <" + srv.URL + "/gone>.",
+		"Bare " + srv.URL + "/gone, `" + srv.URL + "/code` and [local](docs/x.md).",
+		"<img src=\"" + srv.URL + "/img.png\"> <!-- " + srv.URL + "/comment -->",
+		"",
+		"[ref]: " + srv.URL + "/ok",
+	}, "\n")
+
+	links := ExternalLinks(input)
+	var got []string
+	for _, l := range links {
+		got = append(got, strings.TrimPrefix(l.URL, srv.URL))
+	}
+	if strings.Join(got, " ") != "/ok /gone /gone /img.png /ok" {
+		t.Errorf("ExternalLinks() = %v", got)
+	}
+
+	if v := (&MD077{}).Lint(input, "test.md"); len(v) != 0 {
+		t.Errorf("disabled rule reported %+v", v)
+	}
+
+	rule := &MD077{Enabled: true, Checker: linkcheck.New(linkcheck.Options{Client: srv.Client(), HostInterval: -1})}
+	violations := rule.Lint(input, "test.md")
+	if len(violations) != 3 {
+		t.Fatalf("got %d violations, want 3: %+v", len(violations), violations)
+	}
+	v := violations[0]
+	if v.Line != 1 || v.Column != strings.Index(input, "<"+srv.URL)+2 || v.Message != "Dead link (404 Not Found): "+srv.URL+"/gone" || v.Fixable {
+		t.Errorf("violation = %+v", v)
+	}
+
+	rule.Checker = linkcheck.New(linkcheck.Options{Client: srv.Client(), Deny: []string{"127.0.0.1"}})
+	if v := rule.Lint(input, "test.md"); len(v) != 0 {
+		t.Errorf("denied host reported %+v", v)
+	}
+
+	if _, err := ExternalLinkOptions(config.RuleConfig{Timeout: "soon"}); err == nil {
+		t.Error("invalid timeout should be an error")
+	}
+
+	enabled := true
+	invalid := Configure(&MD077{}, config.RuleConfig{Enabled: &enabled, Timeout: "10"}, nil)
+	if v := invalid.Lint(input, "test.md"); len(v) != 1 || !strings.Contains(v[0].Message, "MD077 timeout") {
+		t.Errorf("invalid options reported %+v", v)
+	}
+}
+
+func TestHeadingAnchorStyles(t *testing.T) {
+	input := strings.Join([]string{
+		"# Café & Co",
+		"",
+		"## Setup {#install}",
+		"",
+		"## FAQ",
+		"",
+		"## FAQ",
+		"",
+		`<a name="legacy"></a> <div id="box">x</div>`,
+		"",
+		"```md",
+		"# Not a heading",
+		"```",
+	}, "\n")
+
+	tests := []struct {
+		style string
+		valid []string
+		bad   []string
+	}{
+		{"", []string{"café--co", "setup-install", "faq", "faq-1", "legacy", "box"}, []string{"install", "cafe-co", "not-a-heading"}},
+		{"gitlab", []string{"café-co", "faq-1"}, []string{"café--co"}},
+		{"mkdocs", []string{"cafe-co", "install", "faq", "faq_1", "legacy"}, []string{"setup", "faq-1"}},
+		{"docusaurus", []string{"café--co", "install", "faq-1"}, []string{"setup-install"}},
+	}
+	for _, tt := range tests {
+		rule := &MD051{SuggestClosest: false, SlugStyle: tt.style}
+		for _, frag := range tt.valid {
+			if v := rule.Lint(input+"\n\n[x](#"+frag+")\n", "test.md"); len(v) != 0 {
+				t.Errorf("%q: #%s reported: %+v", tt.style, frag, v)
+			}
+		}
+		for _, frag := range tt.bad {
+			if v := rule.Lint(input+"\n\n[x](#"+frag+")\n", "test.md"); len(v) != 1 {
+				t.Errorf("%q: #%s got %d violations, want 1", tt.style, frag, len(v))
+			}
+		}
+	}
+
+	if v := (&MD051{}).Lint("# Café\n\n[x](#caf%C3%A9)\n", "test.md"); len(v) != 0 {
+		t.Errorf("percent-encoded fragment reported: %+v", v)
+	}
+
+	toc := &MD073{Enabled: true, MinLevel: 2, MaxLevel: 4, SlugStyle: "mkdocs"}
+	doc := "# Title\n<!-- toc -->\n<!-- /toc -->\n\n## Setup {#install}\n\n## FAQ\n\n## FAQ\n"
+	want := "- [Setup](#install)\n- [FAQ](#faq)\n- [FAQ](#faq_1)"
+	if got := toc.Fix(doc, "test.md").Content(); !strings.Contains(got, want) {
+		t.Errorf("MD073 mkdocs toc = %q, want it to contain %q", got, want)
+	}
+}
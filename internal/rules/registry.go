@@ -117,6 +117,7 @@ func RulesByPhase(phase Phase) []Rule {
 		"MD051": PhaseInline,
 		"MD052": PhaseInline,
 		"MD054": PhaseInline,
+		"MD059": PhaseInline,
 		"MD057": PhaseInline,
 		"MD066": PhaseInline,
 		"MD067": PhaseInline,
@@ -130,6 +131,7 @@ func RulesByPhase(phase Phase) []Rule {
 		"MD049": PhaseStyle,
 		"MD050": PhaseStyle,
 		"MD055": PhaseStyle,
+		"MD060": PhaseStyle,
 
 		"MD034": PhaseHeuristic,
 		"MD040": PhaseHeuristic,
@@ -137,6 +139,10 @@ func RulesByPhase(phase Phase) []Rule {
 		"MD053": PhaseCleanup,
 		"MD070": PhaseCleanup,
 		"MD073": PhaseCleanup,
+		"MD074": PhaseInline,
+		"MD075": PhaseInline,
+		"MD076": PhaseInline,
+		"MD077": PhaseInline,
 	}
 
 	mu.RLock()
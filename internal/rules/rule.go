@@ -9,6 +9,12 @@ type Violation struct {
 	Message   string
 	Fixable   bool
 	Suggested string
+	// EndColumn, when set, ends the text that Suggested replaces
+	// (exclusive), so editors can offer the suggestion as a quick fix.
+	EndColumn int
+	// Severity is empty for most rules; reporters then derive a level
+	// from Fixable.
+	Severity Severity
 }
 
 type FixResult struct {
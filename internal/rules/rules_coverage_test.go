@@ -59,11 +59,17 @@ var rulesWithDedicatedTests = map[string]struct{}{
 	"MD056": {},
 	"MD057": {},
 	"MD058": {},
+	"MD059": {},
+	"MD060": {},
 	"MD066": {},
 	"MD067": {},
 	"MD068": {},
 	"MD070": {},
 	"MD073": {},
+	"MD074": {},
+	"MD075": {},
+	"MD076": {},
+	"MD077": {},
 }
 
 func TestRuleTestCoverage(t *testing.T) {
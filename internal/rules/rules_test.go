@@ -527,6 +527,11 @@ func TestMD044Fix(t *testing.T) {
 			input:   "javascript on github\n",
 			wantFix: "JavaScript on GitHub\n",
 		},
+		{
+			name:    "empty file",
+			input:   "",
+			wantFix: "",
+		},
 	}
 
 	for _, tt := range tests {
@@ -2368,7 +2373,33 @@ func TestMD013Fix(t *testing.T) {
 	input := "short line\n"
 	result := rule.Fix(input, "test.md")
 	if result.Changed {
-		t.Error("MD013.Fix() should never change content")
+		t.Error("MD013.Fix() should not change content without reflow")
+	}
+}
+
+func TestMD013Reflow(t *testing.T) {
+	input := "Short paragraph. Two sentences.\n\nThis line is far too long for the limit and has `code spans` in it.\n\n```\n" +
+		strings.Repeat("x", 50) + "\n```\n"
+
+	wrap := &MD013{LineLength: 32, Enabled: true, Reflow: "wrap"}
+	violations := wrap.Lint(input, "test.md")
+	if len(violations) != 1 || violations[0].Line != 3 || !violations[0].Fixable {
+		t.Fatalf("wrap Lint() = %+v, want one fixable violation on line 3", violations)
+	}
+	result := wrap.Fix(input, "test.md")
+	want := "Short paragraph. Two sentences.\n\nThis line is far too long for\nthe limit and has `code spans`\nin it.\n\n```\n" +
+		strings.Repeat("x", 50) + "\n```\n"
+	if got := strings.Join(result.Lines, "\n"); !result.Changed || got != want {
+		t.Errorf("wrap Fix() =\n%s\nwant:\n%s", got, want)
+	}
+
+	sentence := &MD013{LineLength: 80, Enabled: true, Reflow: "sentence"}
+	violations = sentence.Lint(input, "test.md")
+	if len(violations) != 1 || violations[0].Line != 1 || violations[0].Message != "Paragraph should have one sentence per line" {
+		t.Fatalf("sentence Lint() = %+v, want one violation on line 1", violations)
+	}
+	if got := sentence.Fix(input, "test.md").Lines[:2]; got[0] != "Short paragraph." || got[1] != "Two sentences." {
+		t.Errorf("sentence Fix() = %q", got)
 	}
 }
 
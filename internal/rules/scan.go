@@ -148,26 +148,7 @@ var (
 	autolinkRegex       = regexp.MustCompile(`<https?://[^>]+>`)
 	urlInlineLinkRegex  = regexp.MustCompile(`\[https?://[^\]]+\]\(https?://[^)]+\)`)
 	refDefRegex         = regexp.MustCompile(`^\[([^\]]+)\]:\s+(\S+)`)
-	tocMarkerStartRegex = regexp.MustCompile(`<!--\s*toc\s*-->`)
-	tocMarkerStopRegex  = regexp.MustCompile(`<!--\s*(?:/toc|tocstop)\s*-->`)
-	tocItemRegex        = regexp.MustCompile(`^\s*[-*+]\s+\[([^\]]+)\]\((#[^)]+)\)`)
+	tocMarkerStartRegex = regexp.MustCompile(`<!--\s*(?:toc|START doctoc[^>]*?)\s*-->`)
+	tocMarkerStopRegex  = regexp.MustCompile(`<!--\s*(?:/toc|tocstop|END doctoc[^>]*?)\s*-->`)
+	tocItemRegex        = regexp.MustCompile(`^\s*(?:[-*+]|\d+[.)])\s+\[([^\]]+)\]\((#[^)]+)\)`)
 )
-
-func slugifyHeading(text string) string {
-	text = strings.ToLower(text)
-	var b strings.Builder
-	prevDash := false
-	for _, r := range text {
-		switch {
-		case (r >= 'a' && r <= 'z') || (r >= '0' && r <= '9'):
-			b.WriteRune(r)
-			prevDash = false
-		default:
-			if !prevDash && b.Len() > 0 {
-				b.WriteByte('-')
-				prevDash = true
-			}
-		}
-	}
-	return strings.Trim(b.String(), "-")
-}
@@ -0,0 +1,240 @@
+// Package slug turns heading text into the anchors that Markdown platforms
+// generate for it. Each platform has its own rules for punctuation, Unicode,
+// duplicate headings and explicit {#id} attributes.
+package slug
+
+import (
+	"regexp"
+	"strconv"
+	"strings"
+	"unicode"
+
+	"github.com/mohitmishra786/mdmend/internal/markdown"
+)
+
+const (
+	GitHub     = "github"
+	GitLab     = "gitlab"
+	MkDocs     = "mkdocs"
+	Docusaurus = "docusaurus"
+	Hugo       = "hugo"
+	Pandoc     = "pandoc"
+)
+
+// Styles lists the supported slug styles.
+var Styles = []string{GitHub, GitLab, MkDocs, Docusaurus, Hugo, Pandoc}
+
+// Valid reports whether style names a slug style. The empty style is GitHub.
+func Valid(style string) bool {
+	if style == "" {
+		return true
+	}
+	for _, s := range Styles {
+		if s == style {
+			return true
+		}
+	}
+	return false
+}
+
+type scheme struct {
+	slugify func(string) string
+	// separator joins a duplicate anchor and its count: "-" gives intro-1,
+	// "_" gives intro_1.
+	separator   string
+	explicitIDs bool
+}
+
+var schemes = map[string]scheme{
+	GitHub:     {slugify: githubSlug, separator: "-"},
+	GitLab:     {slugify: gitlabSlug, separator: "-"},
+	MkDocs:     {slugify: mkdocsSlug, separator: "_", explicitIDs: true},
+	Docusaurus: {slugify: githubSlug, separator: "-", explicitIDs: true},
+	Hugo:       {slugify: githubSlug, separator: "-", explicitIDs: true},
+	Pandoc:     {slugify: pandocSlug, separator: "-", explicitIDs: true},
+}
+
+func schemeFor(style string) scheme {
+	if s, ok := schemes[style]; ok {
+		return s
+	}
+	return schemes[GitHub]
+}
+
+// Slugger assigns anchors to the headings of one document in order, so
+// that repeated headings get the platform's numeric suffix.
+type Slugger struct {
+	scheme scheme
+	seen   map[string]bool
+	counts map[string]int
+}
+
+// New returns a Slugger for style; unknown styles fall back to GitHub.
+func New(style string) *Slugger {
+	return &Slugger{scheme: schemeFor(style), seen: make(map[string]bool), counts: make(map[string]int)}
+}
+
+// Heading returns the anchor for a heading's Markdown text and the text
+// without any explicit ID attribute.
+func (s *Slugger) Heading(text string) (anchor, rest string) {
+	if s.scheme.explicitIDs {
+		if rest, id, ok := ExplicitID(text); ok {
+			s.seen[id] = true
+			return id, rest
+		}
+	}
+	return s.unique(s.scheme.slugify(Plain(text))), text
+}
+
+// unique appends the next free count to base. Python-Markdown also numbers
+// an empty anchor, giving _1.
+func (s *Slugger) unique(base string) string {
+	id := base
+	for s.seen[id] || (id == "" && s.scheme.separator == "_") {
+		s.counts[base]++
+		id = base + s.scheme.separator + strconv.Itoa(s.counts[base])
+	}
+	s.seen[id] = true
+	return id
+}
+
+// Slug returns the anchor for text without tracking duplicates.
+func Slug(style, text string) string {
+	return schemeFor(style).slugify(Plain(text))
+}
+
+var explicitIDRegex = regexp.MustCompile(`\s*\{:?\s*#([^\s}]+)[^}]*\}\s*$`)
+
+// ExplicitID splits a trailing {#id} or {: #id .class} attribute from
+// heading text.
+func ExplicitID(text string) (rest, id string, ok bool) {
+	m := explicitIDRegex.FindStringSubmatchIndex(text)
+	if m == nil {
+		return text, "", false
+	}
+	return text[:m[0]], text[m[2]:m[3]], true
+}
+
+var (
+	plainImageRegex = regexp.MustCompile(`!\[([^\]]*)\]\([^)]*\)`)
+	plainLinkRegex  = regexp.MustCompile(`\[([^\]]*)\](?:\([^)]*\)|\[[^\]]*\])`)
+	plainAutoRegex  = regexp.MustCompile(`<((?:https?|mailto):[^>]*)>`)
+	plainTagRegex   = regexp.MustCompile(`</?[A-Za-z][A-Za-z0-9-]*(?:\s[^<>]*)?/?>`)
+	plainEmphRegex  = regexp.MustCompile(`\*+|~~|(?:^|[\s\p{P}])_+|_+(?:$|[\s\p{P}])`)
+)
+
+// Plain returns the text a heading renders as: link and image text without
+// their destinations, inline code without backticks, and no emphasis
+// markers or HTML tags.
+func Plain(text string) string {
+	var b strings.Builder
+	last := 0
+	for _, span := range markdown.FindInlineCodeSpans(text) {
+		b.WriteString(plainMarkup(text[last:span.Start]))
+		b.WriteString(strings.TrimSpace(strings.Trim(text[span.Start:span.End], "`")))
+		last = span.End
+	}
+	b.WriteString(plainMarkup(text[last:]))
+	return strings.TrimSpace(b.String())
+}
+
+func plainMarkup(s string) string {
+	s = plainImageRegex.ReplaceAllString(s, "$1")
+	s = plainLinkRegex.ReplaceAllString(s, "$1")
+	s = plainAutoRegex.ReplaceAllString(s, "$1")
+	s = plainTagRegex.ReplaceAllString(s, "")
+	return plainEmphRegex.ReplaceAllStringFunc(s, func(m string) string {
+		return strings.Trim(m, "*~_")
+	})
+}
+
+// githubSlug follows github-slugger, which Docusaurus also uses: lowercase,
+// drop punctuation and symbols, and turn each space into a hyphen.
+func githubSlug(text string) string {
+	var b strings.Builder
+	for _, r := range strings.ToLower(text) {
+		switch {
+		case r == ' ':
+			b.WriteByte('-')
+		case r == '-' || r == '_' || unicode.IsLetter(r) || unicode.IsNumber(r) || unicode.IsMark(r):
+			b.WriteRune(r)
+		}
+	}
+	return b.String()
+}
+
+// gitlabSlug drops non-word characters, turns spaces into hyphens and
+// collapses runs of hyphens.
+func gitlabSlug(text string) string {
+	var b strings.Builder
+	dash := false
+	for _, r := range strings.ToLower(text) {
+		switch {
+		case r == ' ' || r == '-':
+			if !dash {
+				b.WriteByte('-')
+			}
+			dash = true
+		case r == '_' || unicode.IsLetter(r) || unicode.IsNumber(r) || unicode.IsMark(r):
+			b.WriteRune(r)
+			dash = false
+		}
+	}
+	return b.String()
+}
+
+// mkdocsSlug follows Python-Markdown's toc extension: fold to ASCII, drop
+// everything but word characters, spaces and hyphens, and collapse runs of
+// spaces and hyphens into one hyphen.
+func mkdocsSlug(text string) string {
+	var b strings.Builder
+	for _, r := range text {
+		if folded, ok := asciiFold[r]; ok {
+			r = folded
+		}
+		if r <= unicode.MaxASCII && (r == '_' || r == '-' || unicode.IsSpace(r) || unicode.IsLetter(r) || unicode.IsDigit(r)) {
+			b.WriteRune(unicode.ToLower(r))
+		}
+	}
+	return mkdocsSeparatorRegex.ReplaceAllString(strings.TrimSpace(b.String()), "-")
+}
+
+var mkdocsSeparatorRegex = regexp.MustCompile(`[-\s]+`)
+
+// pandocSlug follows pandoc's auto_identifiers: keep letters, digits, _, -
+// and ., join the remaining words with hyphens, lowercase, drop everything
+// before the first letter, and fall back to "section".
+func pandocSlug(text string) string {
+	var b strings.Builder
+	for _, r := range strings.ToLower(text) {
+		if unicode.IsSpace(r) || r == '_' || r == '-' || r == '.' || unicode.IsLetter(r) || unicode.IsNumber(r) {
+			b.WriteRune(r)
+		}
+	}
+	s := strings.Join(strings.Fields(b.String()), "-")
+	if i := strings.IndexFunc(s, unicode.IsLetter); i >= 0 {
+		return s[i:]
+	}
+	return "section"
+}
+
+// asciiFold maps accented Latin letters to the letter NFKD decomposition
+// leaves once combining marks are dropped.
+var asciiFold = func() map[rune]rune {
+	m := make(map[rune]rune)
+	for base, accented := range map[rune]string{
+		'A': "ÀÁÂÃÄÅĀĂĄ", 'a': "àáâãäåāăą", 'C': "ÇĆĈĊČ", 'c': "çćĉċč",
+		'D': "Ď", 'd': "ď", 'E': "ÈÉÊËĒĔĖĘĚ", 'e': "èéêëēĕėęě",
+		'G': "ĜĞĠĢ", 'g': "ĝğġģ", 'H': "Ĥ", 'h': "ĥ", 'I': "ÌÍÎÏĨĪĬĮİ", 'i': "ìíîïĩīĭį",
+		'J': "Ĵ", 'j': "ĵ", 'K': "Ķ", 'k': "ķ", 'L': "ĹĻĽ", 'l': "ĺļľ",
+		'N': "ÑŃŅŇ", 'n': "ñńņň", 'O': "ÒÓÔÕÖŌŎŐ", 'o': "òóôõöōŏő",
+		'R': "ŔŖŘ", 'r': "ŕŗř", 'S': "ŚŜŞŠ", 's': "śŝşš", 'T': "ŢŤ", 't': "ţť",
+		'U': "ÙÚÛÜŨŪŬŮŰŲ", 'u': "ùúûüũūŭůűų", 'W': "Ŵ", 'w': "ŵ",
+		'Y': "ÝŶŸ", 'y': "ýÿŷ", 'Z': "ŹŻŽ", 'z': "źżž",
+	} {
+		for _, r := range accented {
+			m[r] = base
+		}
+	}
+	return m
+}()
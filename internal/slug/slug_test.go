@@ -0,0 +1,85 @@
+package slug
+
+import "testing"
+
+func TestSlug(t *testing.T) {
+	tests := []struct {
+		style string
+		text  string
+		want  string
+	}{
+		{GitHub, "Hello, World!", "hello-world"},
+		{GitHub, "a - b", "a---b"},
+		{GitHub, "Café au lait", "café-au-lait"},
+		{GitHub, "Use `my_var` and *emphasis*", "use-my_var-and-emphasis"},
+		{GitHub, "See [the docs](https://example.com)", "see-the-docs"},
+		{GitHub, "1.2 Release", "12-release"},
+		{GitLab, "a - b", "a-b"},
+		{GitLab, "Café & Co", "café-co"},
+		{MkDocs, "Café & Co", "cafe-co"},
+		{MkDocs, "a - b", "a-b"},
+		{MkDocs, "  Über _private_ ", "uber-private"},
+		{Docusaurus, "Hello, World!", "hello-world"},
+		{Hugo, "Hello, World!", "hello-world"},
+		{Pandoc, "1.2 Release Notes", "release-notes"},
+		{Pandoc, "Version 1.2", "version-1.2"},
+		{Pandoc, "123", "section"},
+		{Pandoc, "Café & Co", "café-co"},
+		{Hugo, "Café & Co", "café--co"},
+		{"", "Hello World", "hello-world"},
+	}
+	for _, tt := range tests {
+		if got := Slug(tt.style, tt.text); got != tt.want {
+			t.Errorf("Slug(%q, %q) = %q, want %q", tt.style, tt.text, got, tt.want)
+		}
+	}
+}
+
+func TestSluggerDuplicates(t *testing.T) {
+	tests := []struct {
+		style    string
+		headings []string
+		want     []string
+	}{
+		{GitHub, []string{"Intro", "Intro", "Intro-1", "Intro"}, []string{"intro", "intro-1", "intro-1-1", "intro-2"}},
+		{MkDocs, []string{"Intro", "Intro", "Intro"}, []string{"intro", "intro_1", "intro_2"}},
+		{MkDocs, []string{"!!!", "???"}, []string{"_1", "_2"}},
+		{Pandoc, []string{"Intro", "Intro"}, []string{"intro", "intro-1"}},
+	}
+	for _, tt := range tests {
+		s := New(tt.style)
+		for i, h := range tt.headings {
+			if got, _ := s.Heading(h); got != tt.want[i] {
+				t.Errorf("%s: heading %d %q = %q, want %q", tt.style, i, h, got, tt.want[i])
+			}
+		}
+	}
+}
+
+func TestExplicitID(t *testing.T) {
+	for _, style := range []string{MkDocs, Docusaurus, Hugo, Pandoc} {
+		s := New(style)
+		anchor, rest := s.Heading("Getting started {#setup}")
+		if anchor != "setup" || rest != "Getting started" {
+			t.Errorf("%s: Heading() = %q, %q, want setup, Getting started", style, anchor, rest)
+		}
+	}
+
+	if anchor, _ := New(MkDocs).Heading("Install {: #install .wide }"); anchor != "install" {
+		t.Errorf("attribute list anchor = %q, want install", anchor)
+	}
+	if anchor, _ := New(GitHub).Heading("Getting started {#setup}"); anchor != "getting-started-setup" {
+		t.Errorf("GitHub anchor = %q, want getting-started-setup", anchor)
+	}
+}
+
+func TestValid(t *testing.T) {
+	for _, style := range append([]string{""}, Styles...) {
+		if !Valid(style) {
+			t.Errorf("Valid(%q) = false", style)
+		}
+	}
+	if Valid("kramdown") {
+		t.Error("Valid(kramdown) = true")
+	}
+}
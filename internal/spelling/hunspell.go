@@ -0,0 +1,225 @@
+package spelling
+
+import (
+	"bufio"
+	"fmt"
+	"os"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+type affix struct {
+	prefix    bool
+	cross     bool
+	strip     string
+	add       string
+	condition *regexp.Regexp
+}
+
+type affixFile struct {
+	flagType string
+	latin1   bool
+	rules    map[string][]affix
+}
+
+// LoadHunspell reads a Hunspell .dic file and the .aff file next to it, and
+// expands every word with its prefix and suffix rules. Compounding and
+// morphology are not supported. A missing .aff file means no affixes.
+func LoadHunspell(dicPath string) (*Dictionary, error) {
+	aff := &affixFile{rules: make(map[string][]affix)}
+	affPath := strings.TrimSuffix(dicPath, ".dic") + ".aff"
+	if _, err := os.Stat(affPath); err == nil {
+		if err := aff.load(affPath); err != nil {
+			return nil, fmt.Errorf("%s: %w", affPath, err)
+		}
+	}
+
+	f, err := os.Open(dicPath)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	d := newDictionary()
+	scanner := bufio.NewScanner(f)
+	first := true
+	for scanner.Scan() {
+		line := aff.decode(scanner.Text())
+		if first {
+			first = false
+			// The first line is the approximate word count.
+			if _, err := strconv.Atoi(strings.TrimSpace(line)); err == nil {
+				continue
+			}
+		}
+		if i := strings.IndexAny(line, " \t"); i >= 0 {
+			line = line[:i]
+		}
+		if line == "" {
+			continue
+		}
+		word, flags := line, ""
+		if i := strings.Index(line, "/"); i > 0 {
+			word, flags = line[:i], line[i+1:]
+		}
+		for _, form := range aff.expand(word, flags) {
+			d.add(form)
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("%s: %w", dicPath, err)
+	}
+	return d, nil
+}
+
+func (a *affixFile) load(path string) error {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return err
+	}
+	cross := make(map[string]bool)
+	for n, line := range strings.Split(string(data), "\n") {
+		fields := strings.Fields(a.decode(line))
+		if len(fields) < 2 || strings.HasPrefix(fields[0], "#") {
+			continue
+		}
+		switch fields[0] {
+		case "FLAG":
+			a.flagType = fields[1]
+		case "SET":
+			a.latin1 = strings.EqualFold(fields[1], "ISO8859-1")
+		case "PFX", "SFX":
+			// A header reads "SFX flag cross count"; a rule reads
+			// "SFX flag strip add condition".
+			if len(fields) == 4 {
+				cross[fields[1]] = fields[2] == "Y"
+				continue
+			}
+			if len(fields) < 5 {
+				return fmt.Errorf("line %d: malformed %s rule", n+1, fields[0])
+			}
+			rule, err := newAffix(fields[0] == "PFX", fields[2], fields[3], fields[4])
+			if err != nil {
+				return fmt.Errorf("line %d: %w", n+1, err)
+			}
+			rule.cross = cross[fields[1]]
+			a.rules[fields[1]] = append(a.rules[fields[1]], rule)
+		}
+	}
+	return nil
+}
+
+func newAffix(prefix bool, strip, add, condition string) (affix, error) {
+	if strip == "0" {
+		strip = ""
+	}
+	if i := strings.Index(add, "/"); i >= 0 {
+		add = add[:i] // continuation classes are not supported
+	}
+	if add == "0" {
+		add = ""
+	}
+	pattern := condition + "$"
+	if prefix {
+		pattern = "^" + condition
+	}
+	if condition == "." {
+		pattern = ""
+	}
+	re, err := regexp.Compile(pattern)
+	if err != nil {
+		return affix{}, fmt.Errorf("condition %q: %w", condition, err)
+	}
+	return affix{prefix: prefix, strip: strip, add: add, condition: re}, nil
+}
+
+func (a affix) apply(word string) (string, bool) {
+	if !a.condition.MatchString(word) {
+		return "", false
+	}
+	if a.prefix {
+		if !strings.HasPrefix(word, a.strip) {
+			return "", false
+		}
+		return a.add + word[len(a.strip):], true
+	}
+	if !strings.HasSuffix(word, a.strip) {
+		return "", false
+	}
+	return word[:len(word)-len(a.strip)] + a.add, true
+}
+
+func (a *affixFile) expand(word, flags string) []string {
+	forms := []string{word}
+	var prefixes, suffixes []affix
+	for _, flag := range a.splitFlags(flags) {
+		for _, rule := range a.rules[flag] {
+			if rule.prefix {
+				prefixes = append(prefixes, rule)
+			} else {
+				suffixes = append(suffixes, rule)
+			}
+		}
+	}
+
+	var suffixed []string
+	for _, s := range suffixes {
+		if form, ok := s.apply(word); ok {
+			forms = append(forms, form)
+			if s.cross {
+				suffixed = append(suffixed, form)
+			}
+		}
+	}
+	for _, p := range prefixes {
+		if form, ok := p.apply(word); ok {
+			forms = append(forms, form)
+		}
+		if !p.cross {
+			continue
+		}
+		for _, base := range suffixed {
+			if form, ok := p.apply(base); ok {
+				forms = append(forms, form)
+			}
+		}
+	}
+	return forms
+}
+
+func (a *affixFile) splitFlags(flags string) []string {
+	if flags == "" {
+		return nil
+	}
+	switch a.flagType {
+	case "long":
+		var out []string
+		for i := 0; i+1 < len(flags); i += 2 {
+			out = append(out, flags[i:i+2])
+		}
+		return out
+	case "num":
+		return strings.Split(flags, ",")
+	default:
+		var out []string
+		for _, r := range flags {
+			out = append(out, string(r))
+		}
+		return out
+	}
+}
+
+// decode converts an ISO 8859-1 line to UTF-8; other encodings are read as
+// UTF-8.
+func (a *affixFile) decode(line string) string {
+	line = strings.TrimRight(line, "\r")
+	if !a.latin1 {
+		return line
+	}
+	runes := make([]rune, len(line))
+	for i := 0; i < len(line); i++ {
+		runes[i] = rune(line[i])
+	}
+	return string(runes)
+}
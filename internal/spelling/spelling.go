@@ -0,0 +1,329 @@
+// Package spelling checks the prose of Markdown documents against an
+// embedded English word list, project word lists and Hunspell dictionaries.
+package spelling
+
+import (
+	"bufio"
+	_ "embed"
+	"io"
+	"os"
+	"strings"
+	"sync"
+	"unicode"
+	"unicode/utf8"
+
+	"github.com/mohitmishra786/mdmend/internal/markdown"
+)
+
+// WordsFile is the name of a project word list. Every such file in a
+// document's directory or one of its parents applies to the document.
+const WordsFile = ".mdmend-words.txt"
+
+//go:embed en.txt
+var englishWords string
+
+var (
+	englishOnce sync.Once
+	english     *Dictionary
+)
+
+// Dictionary is a set of accepted words. Lookups ignore case.
+type Dictionary struct {
+	// rank orders words from most to least common and ranks suggestions.
+	rank map[string]int
+}
+
+func newDictionary() *Dictionary {
+	return &Dictionary{rank: make(map[string]int)}
+}
+
+// English returns the embedded English word list.
+func English() *Dictionary {
+	englishOnce.Do(func() {
+		english, _ = ParseWords(strings.NewReader(englishWords))
+	})
+	return english
+}
+
+// Words builds a dictionary from a list of words.
+func Words(words ...string) *Dictionary {
+	d := newDictionary()
+	for _, w := range words {
+		d.add(w)
+	}
+	return d
+}
+
+// ParseWords reads a word list with one word per line. Blank lines and
+// lines starting with # are ignored.
+func ParseWords(r io.Reader) (*Dictionary, error) {
+	d := newDictionary()
+	scanner := bufio.NewScanner(r)
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		d.add(line)
+	}
+	return d, scanner.Err()
+}
+
+// LoadWords reads a word list file.
+func LoadWords(path string) (*Dictionary, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+	return ParseWords(f)
+}
+
+func (d *Dictionary) add(word string) {
+	w := normalize(word)
+	if w == "" {
+		return
+	}
+	if _, ok := d.rank[w]; !ok {
+		d.rank[w] = len(d.rank)
+	}
+}
+
+// Has reports whether word is in the dictionary as written.
+func (d *Dictionary) Has(word string) bool {
+	_, ok := d.rank[normalize(word)]
+	return ok
+}
+
+func (d *Dictionary) Len() int {
+	return len(d.rank)
+}
+
+func normalize(word string) string {
+	return strings.ToLower(strings.ReplaceAll(word, "’", "'"))
+}
+
+// Misspelling is an unknown word in a document. Start and End are byte
+// offsets into line Line (1-based).
+type Misspelling struct {
+	Line        int
+	Start       int
+	End         int
+	Word        string
+	Suggestions []string
+}
+
+// Checker checks words against a stack of dictionaries.
+type Checker struct {
+	dicts       []*Dictionary
+	suggestions map[string][]string
+	mu          sync.Mutex
+}
+
+func NewChecker(dicts ...*Dictionary) *Checker {
+	c := &Checker{suggestions: make(map[string][]string)}
+	for _, d := range dicts {
+		if d != nil {
+			c.dicts = append(c.dicts, d)
+		}
+	}
+	return c
+}
+
+func (c *Checker) has(word string) bool {
+	for _, d := range c.dicts {
+		if _, ok := d.rank[word]; ok {
+			return true
+		}
+	}
+	return false
+}
+
+// Known reports whether word, or a regular inflection or prefixed form of
+// a known word, is in one of the dictionaries.
+func (c *Checker) Known(word string) bool {
+	w := normalize(word)
+	if c.has(w) {
+		return true
+	}
+	if i := strings.IndexByte(w, '\''); i > 0 {
+		switch w[i+1:] {
+		case "", "s", "t", "re", "ve", "ll", "d", "m":
+			return c.knownStem(w[:i])
+		}
+		return false
+	}
+	if c.knownStem(w) {
+		return true
+	}
+	for _, prefix := range prefixes {
+		if rest := strings.TrimPrefix(w, prefix); rest != w && len(rest) >= 3 {
+			if c.has(rest) || c.knownStem(rest) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+var prefixes = []string{"re", "un", "non", "pre", "sub", "de", "dis", "mis", "co", "auto", "multi", "over", "under", "inter"}
+
+// suffixes maps an inflection to the endings it may have replaced.
+var suffixes = []struct {
+	suffix  string
+	endings []string
+}{
+	{"'s", []string{""}},
+	{"ies", []string{"y"}},
+	{"ied", []string{"y"}},
+	{"ier", []string{"y"}},
+	{"iest", []string{"y"}},
+	{"ily", []string{"y"}},
+	{"es", []string{""}},
+	{"s", []string{""}},
+	{"ed", []string{"", "e"}},
+	{"ing", []string{"", "e"}},
+	{"er", []string{"", "e"}},
+	{"ers", []string{"", "e"}},
+	{"est", []string{"", "e"}},
+	{"ly", []string{"", "le"}},
+	{"ness", []string{""}},
+	{"ment", []string{""}},
+	{"able", []string{"", "e"}},
+	{"less", []string{""}},
+	{"ful", []string{""}},
+}
+
+func (c *Checker) knownStem(w string) bool {
+	if c.has(w) {
+		return true
+	}
+	for _, s := range suffixes {
+		stem := strings.TrimSuffix(w, s.suffix)
+		if stem == w || len(stem) < 2 {
+			continue
+		}
+		for _, ending := range s.endings {
+			if c.has(stem + ending) {
+				return true
+			}
+		}
+		// stopped, running: a doubled final consonant.
+		if n := len(stem); n >= 3 && stem[n-1] == stem[n-2] && c.has(stem[:n-1]) {
+			return true
+		}
+	}
+	return false
+}
+
+// Check returns the unknown words in the prose of content, ordered by
+// position, each with up to three suggestions.
+func (c *Checker) Check(content string) []Misspelling {
+	var out []Misspelling
+	for i, line := range markdown.ProseLines(content) {
+		for _, w := range words(line) {
+			word := line[w[0]:w[1]]
+			if c.Known(word) {
+				continue
+			}
+			out = append(out, Misspelling{
+				Line:        i + 1,
+				Start:       w[0],
+				End:         w[1],
+				Word:        word,
+				Suggestions: c.Suggest(word, 3),
+			})
+		}
+	}
+	return out
+}
+
+// MinWordLength is the length below which words are never checked.
+const MinWordLength = 3
+
+// words returns the byte ranges of the checkable words in line. Chunks that
+// look like identifiers, paths, versions or addresses are skipped, as are
+// acronyms and mixed-case names.
+func words(line string) [][2]int {
+	var out [][2]int
+	start := -1
+	flush := func(end int) {
+		if start < 0 {
+			return
+		}
+		out = append(out, chunkWords(line, start, end)...)
+		start = -1
+	}
+	for i, r := range line {
+		if isSeparator(r) {
+			flush(i)
+			continue
+		}
+		if start < 0 {
+			start = i
+		}
+	}
+	flush(len(line))
+	return out
+}
+
+func isSeparator(r rune) bool {
+	if unicode.IsSpace(r) {
+		return true
+	}
+	return strings.ContainsRune("()[]{}<>\"*~|`,;!?“”", r)
+}
+
+// chunkWords trims punctuation from line[start:end] and splits it on
+// hyphens into words.
+func chunkWords(line string, start, end int) [][2]int {
+	for start < end {
+		r, size := utf8.DecodeRuneInString(line[start:end])
+		if !strings.ContainsRune("'’.:_#", r) {
+			break
+		}
+		start += size
+	}
+	for end > start {
+		r, size := utf8.DecodeLastRuneInString(line[start:end])
+		if !strings.ContainsRune("'’.:_", r) {
+			break
+		}
+		end -= size
+	}
+	chunk := line[start:end]
+	if chunk == "" || strings.ContainsAny(chunk, "./\\@:_=+&%$#^0123456789") {
+		return nil
+	}
+
+	var out [][2]int
+	offset := start
+	for _, part := range strings.Split(chunk, "-") {
+		if isWord(part) {
+			out = append(out, [2]int{offset, offset + len(part)})
+		}
+		offset += len(part) + 1
+	}
+	return out
+}
+
+// isWord reports whether s is an all-lowercase or capitalised word of
+// letters and apostrophes long enough to check.
+func isWord(s string) bool {
+	if utf8.RuneCountInString(s) < MinWordLength {
+		return false
+	}
+	for i, r := range s {
+		switch {
+		case r == '\'' || r == '’':
+			continue
+		case !unicode.IsLetter(r):
+			return false
+		case unicode.IsUpper(r):
+			if i > 0 {
+				return false
+			}
+		}
+	}
+	return true
+}
@@ -0,0 +1,88 @@
+package spelling
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestCheck(t *testing.T) {
+	content := strings.Join([]string{
+		"---",
+		"title: Teh front matter",
+		"---",
+		"Teh quick fox jumped over the lazy dog's bed.",
+		"Run `mdmend fixx` or see https://example.com/wrod and <a title=\"wrod\">here</a>.",
+		"Configured values, well-known README files, HTTPServer and v1.2.",
+		"",
+		"```",
+		"recieve",
+		"```",
+	}, "\n")
+
+	c := NewChecker(English())
+	ms := c.Check(content)
+	if len(ms) != 1 {
+		t.Fatalf("got %d misspellings, want 1: %+v", len(ms), ms)
+	}
+	m := ms[0]
+	if m.Line != 4 || m.Start != 0 || m.End != 3 || m.Word != "Teh" {
+		t.Errorf("misspelling = %+v", m)
+	}
+	if len(m.Suggestions) == 0 || m.Suggestions[0] != "The" {
+		t.Errorf("suggestions = %v, want The first", m.Suggestions)
+	}
+}
+
+func TestKnown(t *testing.T) {
+	c := NewChecker(English(), Words("mdmend"))
+	for _, w := range []string{"the", "files", "stopped", "configuring", "doesn't", "reinstalled", "Mdmend", "library’s"} {
+		if !c.Known(w) {
+			t.Errorf("Known(%q) = false", w)
+		}
+	}
+	for _, w := range []string{"teh", "recieve", "seperate", "wrod"} {
+		if c.Known(w) {
+			t.Errorf("Known(%q) = true", w)
+		}
+	}
+	if got := c.Suggest("recieve", 1); len(got) != 1 || got[0] != "receive" {
+		t.Errorf("Suggest(recieve) = %v", got)
+	}
+}
+
+func TestParseWords(t *testing.T) {
+	d, err := ParseWords(strings.NewReader("# project words\nKubernetes\n\n  mdmend  \n"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if d.Len() != 2 || !d.Has("kubernetes") || !d.Has("MDMEND") {
+		t.Errorf("dictionary has %d words", d.Len())
+	}
+}
+
+func TestLoadHunspell(t *testing.T) {
+	dir := t.TempDir()
+	aff := "SET UTF-8\n\nSFX S Y 2\nSFX S 0 s [^y]\nSFX S y ies [^aeiou]y\n\nPFX U Y 1\nPFX U 0 un .\n"
+	dic := "3\nwidget/SU\npony/S\nflimflam\n"
+	if err := os.WriteFile(filepath.Join(dir, "x.aff"), []byte(aff), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(dir, "x.dic"), []byte(dic), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	d, err := LoadHunspell(filepath.Join(dir, "x.dic"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, w := range []string{"widget", "widgets", "unwidget", "unwidgets", "pony", "ponies", "flimflam"} {
+		if !d.Has(w) {
+			t.Errorf("missing %q", w)
+		}
+	}
+	if d.Has("ponys") || d.Has("unpony") || d.Has("3") {
+		t.Error("unexpected form expanded")
+	}
+}
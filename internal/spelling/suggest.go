@@ -0,0 +1,125 @@
+package spelling
+
+import (
+	"sort"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+const alphabet = "abcdefghijklmnopqrstuvwxyz"
+
+// Suggest returns up to n known words within two edits of word, closest and
+// most common first, capitalised like word.
+func (c *Checker) Suggest(word string, n int) []string {
+	w := normalize(word)
+
+	c.mu.Lock()
+	cached, ok := c.suggestions[w]
+	c.mu.Unlock()
+	if !ok {
+		cached = c.suggest(w)
+		c.mu.Lock()
+		c.suggestions[w] = cached
+		c.mu.Unlock()
+	}
+
+	if len(cached) > n {
+		cached = cached[:n]
+	}
+	out := make([]string, len(cached))
+	for i, s := range cached {
+		out[i] = matchCase(s, word)
+	}
+	return out
+}
+
+func (c *Checker) suggest(w string) []string {
+	first := edits(w)
+	found := c.ranked(first)
+	if len(found) > 0 || utf8.RuneCountInString(w) > 10 {
+		return found
+	}
+
+	seen := make(map[string]bool)
+	var second []string
+	for _, e := range first {
+		for _, e2 := range edits(e) {
+			if !seen[e2] {
+				seen[e2] = true
+				second = append(second, e2)
+			}
+		}
+	}
+	return c.ranked(second)
+}
+
+// ranked keeps the candidates found in a dictionary, ordered by the rank of
+// their best dictionary.
+func (c *Checker) ranked(candidates []string) []string {
+	type hit struct {
+		word string
+		dict int
+		rank int
+	}
+	var hits []hit
+	seen := make(map[string]bool)
+	for _, cand := range candidates {
+		if seen[cand] {
+			continue
+		}
+		seen[cand] = true
+		for i, d := range c.dicts {
+			if r, ok := d.rank[cand]; ok {
+				hits = append(hits, hit{cand, i, r})
+				break
+			}
+		}
+	}
+	sort.Slice(hits, func(a, b int) bool {
+		if hits[a].rank != hits[b].rank {
+			return hits[a].rank < hits[b].rank
+		}
+		if hits[a].dict != hits[b].dict {
+			return hits[a].dict < hits[b].dict
+		}
+		return hits[a].word < hits[b].word
+	})
+
+	out := make([]string, len(hits))
+	for i, h := range hits {
+		out[i] = h.word
+	}
+	return out
+}
+
+// edits returns every string one deletion, transposition, replacement or
+// insertion away from w.
+func edits(w string) []string {
+	var out []string
+	for i := 0; i <= len(w); i++ {
+		left, right := w[:i], w[i:]
+		if right != "" {
+			out = append(out, left+right[1:])
+		}
+		if len(right) > 1 {
+			out = append(out, left+string(right[1])+string(right[0])+right[2:])
+		}
+		for _, r := range alphabet {
+			if right != "" && byte(r) != right[0] {
+				out = append(out, left+string(r)+right[1:])
+			}
+			out = append(out, left+string(r)+right)
+		}
+	}
+	return out
+}
+
+func matchCase(s, like string) string {
+	r, _ := utf8.DecodeRuneInString(like)
+	if !unicode.IsUpper(r) {
+		return s
+	}
+	first, size := utf8.DecodeRuneInString(s)
+	return strings.ToUpper(string(first)) + s[size:]
+}
@@ -0,0 +1,272 @@
+// Package terminology finds discouraged spellings of preferred terms in the
+// prose of a Markdown document and replaces them where that is safe.
+package terminology
+
+import (
+	"fmt"
+	"os"
+	"regexp"
+	"sort"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	"github.com/mohitmishra786/mdmend/internal/markdown"
+	"gopkg.in/yaml.v3"
+)
+
+// DefaultPath is the vocabulary file used when none is configured.
+const DefaultPath = ".mdmend-terms.yml"
+
+// Term is one entry of a vocabulary file.
+//
+// Variants are literal spellings to reject; the term itself is always
+// accepted and, unless CaseSensitive is set, any other capitalisation of it
+// is rejected too. Patterns are regular expressions for looser matches.
+// Matches inside code are ignored unless AllowedInCode is false, in which
+// case they are reported but never fixed.
+type Term struct {
+	Term          string   `yaml:"term"`
+	Variants      []string `yaml:"variants"`
+	Patterns      []string `yaml:"patterns"`
+	CaseSensitive bool     `yaml:"case_sensitive"`
+	AllowedInCode *bool    `yaml:"allowed_in_code"`
+	Message       string   `yaml:"message"`
+	Severity      string   `yaml:"severity"`
+	Fix           *bool    `yaml:"fix"`
+}
+
+func (t *Term) allowedInCode() bool {
+	return t.AllowedInCode == nil || *t.AllowedInCode
+}
+
+func (t *Term) fixEnabled() bool {
+	return t.Fix == nil || *t.Fix
+}
+
+type matcher struct {
+	term     *Term
+	re       *regexp.Regexp
+	literal  bool
+	priority int
+}
+
+// Vocabulary is a compiled list of terms.
+type Vocabulary struct {
+	Terms    []Term `yaml:"terms"`
+	matchers []matcher
+}
+
+// Match is one discouraged spelling found in a document. Start and End are
+// byte offsets into line Line (1-based).
+type Match struct {
+	Term    *Term
+	Line    int
+	Start   int
+	End     int
+	Text    string
+	InCode  bool
+	Fixable bool
+}
+
+// Message describes the match for a lint report.
+func (m Match) Message() string {
+	if m.Term.Message != "" {
+		return m.Term.Message
+	}
+	return fmt.Sprintf("Use %q instead of %q", m.Term.Term, m.Text)
+}
+
+// Load reads a YAML vocabulary file.
+func Load(path string) (*Vocabulary, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	var v Vocabulary
+	if err := yaml.Unmarshal(data, &v); err != nil {
+		return nil, fmt.Errorf("%s: %w", path, err)
+	}
+	if err := v.compile(); err != nil {
+		return nil, fmt.Errorf("%s: %w", path, err)
+	}
+	return &v, nil
+}
+
+// New compiles terms into a vocabulary.
+func New(terms []Term) (*Vocabulary, error) {
+	v := &Vocabulary{Terms: terms}
+	if err := v.compile(); err != nil {
+		return nil, err
+	}
+	return v, nil
+}
+
+func (v *Vocabulary) compile() error {
+	v.matchers = nil
+	for i := range v.Terms {
+		t := &v.Terms[i]
+		if strings.TrimSpace(t.Term) == "" {
+			return fmt.Errorf("term %d has no term", i+1)
+		}
+		switch strings.ToLower(t.Severity) {
+		case "", "error", "warning", "info":
+		default:
+			return fmt.Errorf("term %q: invalid severity %q", t.Term, t.Severity)
+		}
+
+		flags := "(?i)"
+		if t.CaseSensitive {
+			flags = ""
+		}
+
+		literals := append([]string{t.Term}, t.Variants...)
+		sort.SliceStable(literals, func(a, b int) bool { return len(literals[a]) > len(literals[b]) })
+		alts := make([]string, 0, len(literals))
+		for _, lit := range literals {
+			if lit != "" {
+				alts = append(alts, wordBounded(lit))
+			}
+		}
+		v.matchers = append(v.matchers, matcher{
+			term:    t,
+			re:      regexp.MustCompile(flags + "(?:" + strings.Join(alts, "|") + ")"),
+			literal: true,
+		})
+
+		for _, p := range t.Patterns {
+			re, err := regexp.Compile(flags + p)
+			if err != nil {
+				return fmt.Errorf("term %q: %w", t.Term, err)
+			}
+			v.matchers = append(v.matchers, matcher{term: t, re: re, priority: 1})
+		}
+	}
+	// Literal matches claim text before patterns can.
+	sort.SliceStable(v.matchers, func(a, b int) bool { return v.matchers[a].priority < v.matchers[b].priority })
+	return nil
+}
+
+// wordBounded quotes lit and anchors it at word boundaries where it starts or
+// ends with a word character, so "git" does not match inside "digit".
+func wordBounded(lit string) string {
+	q := regexp.QuoteMeta(lit)
+	if r, _ := utf8.DecodeRuneInString(lit); isWord(r) {
+		q = `\b` + q
+	}
+	if r, _ := utf8.DecodeLastRuneInString(lit); isWord(r) {
+		q += `\b`
+	}
+	return q
+}
+
+func isWord(r rune) bool {
+	return r == '_' || unicode.IsLetter(r) || unicode.IsDigit(r)
+}
+
+// Find returns every discouraged spelling in content, ordered by position.
+// Overlapping matches are reported once, preferring literal matches over
+// patterns.
+func (v *Vocabulary) Find(content string) []Match {
+	if v == nil || len(v.matchers) == 0 {
+		return nil
+	}
+
+	prose := markdown.ProseLines(content)
+	var text []string
+
+	var matches []Match
+	for i, line := range prose {
+		var taken [][2]int
+		for _, m := range v.matchers {
+			for _, loc := range m.re.FindAllStringIndex(line, -1) {
+				if loc[0] == loc[1] || overlaps(taken, loc) {
+					continue
+				}
+				found := line[loc[0]:loc[1]]
+				taken = append(taken, [2]int{loc[0], loc[1]})
+				if found == m.term.Term {
+					continue
+				}
+				matches = append(matches, Match{
+					Term:    m.term,
+					Line:    i + 1,
+					Start:   loc[0],
+					End:     loc[1],
+					Text:    found,
+					Fixable: m.literal && m.term.fixEnabled(),
+				})
+			}
+		}
+
+		for _, m := range v.matchers {
+			if m.term.allowedInCode() {
+				continue
+			}
+			if text == nil {
+				text = markdown.TextLines(content)
+			}
+			for _, loc := range m.re.FindAllStringIndex(text[i], -1) {
+				if loc[0] == loc[1] || overlaps(taken, loc) || strings.TrimSpace(line[loc[0]:loc[1]]) != "" {
+					continue
+				}
+				found := text[i][loc[0]:loc[1]]
+				taken = append(taken, [2]int{loc[0], loc[1]})
+				if found == m.term.Term {
+					continue
+				}
+				matches = append(matches, Match{
+					Term:   m.term,
+					Line:   i + 1,
+					Start:  loc[0],
+					End:    loc[1],
+					Text:   found,
+					InCode: true,
+				})
+			}
+		}
+	}
+
+	sort.SliceStable(matches, func(a, b int) bool {
+		if matches[a].Line != matches[b].Line {
+			return matches[a].Line < matches[b].Line
+		}
+		return matches[a].Start < matches[b].Start
+	})
+	return matches
+}
+
+func overlaps(taken [][2]int, loc []int) bool {
+	for _, t := range taken {
+		if loc[0] < t[1] && loc[1] > t[0] {
+			return true
+		}
+	}
+	return false
+}
+
+// Replace rewrites every fixable match in content with its preferred term
+// and returns the new content and the number of replacements.
+func (v *Vocabulary) Replace(content string) (string, int) {
+	byLine := map[int][]Match{}
+	count := 0
+	for _, m := range v.Find(content) {
+		if m.Fixable {
+			byLine[m.Line] = append(byLine[m.Line], m)
+			count++
+		}
+	}
+	if count == 0 {
+		return content, 0
+	}
+
+	lines := strings.Split(content, "\n")
+	for n, ms := range byLine {
+		line := lines[n-1]
+		for i := len(ms) - 1; i >= 0; i-- {
+			line = line[:ms[i].Start] + ms[i].Term.Term + line[ms[i].End:]
+		}
+		lines[n-1] = line
+	}
+	return strings.Join(lines, "\n"), count
+}
@@ -0,0 +1,67 @@
+package terminology
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestFindAndReplace(t *testing.T) {
+	no := false
+	v, err := New([]Term{
+		{Term: "JavaScript", Variants: []string{"Javascript", "JS"}, CaseSensitive: true},
+		{Term: "e-mail", Patterns: []string{`\bemails?\b`}, Severity: "info"},
+		{Term: "Kubernetes", AllowedInCode: &no},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	content := "Javascript and JS and JavaScript, but not JSON.\nSend an email to `Javascript`.\n\n```\nkubernetes\n```\n"
+	matches := v.Find(content)
+	if len(matches) != 4 {
+		t.Fatalf("got %d matches, want 4: %+v", len(matches), matches)
+	}
+	if m := matches[0]; m.Line != 1 || m.Start != 0 || m.Text != "Javascript" || !m.Fixable {
+		t.Errorf("first match = %+v", m)
+	}
+	if m := matches[2]; m.Text != "email" || m.Fixable {
+		t.Errorf("pattern match = %+v, want unfixable email", m)
+	}
+	if m := matches[3]; m.Line != 5 || !m.InCode || m.Fixable {
+		t.Errorf("code match = %+v, want unfixable kubernetes in code", m)
+	}
+
+	fixed, n := v.Replace(content)
+	want := "JavaScript and JavaScript and JavaScript, but not JSON.\nSend an email to `Javascript`.\n\n```\nkubernetes\n```\n"
+	if n != 2 || fixed != want {
+		t.Errorf("Replace() = %q, %d; want %q, 2", fixed, n, want)
+	}
+}
+
+func TestLoad(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, DefaultPath)
+	data := "terms:\n  - term: GitHub\n    message: Spell it GitHub\n    fix: false\n"
+	if err := os.WriteFile(path, []byte(data), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	v, err := Load(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	matches := v.Find("on github\n")
+	if len(matches) != 1 || matches[0].Fixable || matches[0].Message() != "Spell it GitHub" {
+		t.Errorf("matches = %+v", matches)
+	}
+
+	if err := os.WriteFile(path, []byte("terms:\n  - term: x\n    severity: fatal\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := Load(path); err == nil {
+		t.Error("invalid severity should fail to load")
+	}
+	if _, err := New([]Term{{Term: "x", Patterns: []string{"("}}}); err == nil {
+		t.Error("invalid pattern should fail to compile")
+	}
+}
@@ -0,0 +1,275 @@
+package vcs
+
+import (
+	"bufio"
+	"bytes"
+	"fmt"
+	"os/exec"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// LineSet holds changed line numbers (1-based). A nil LineSet means every
+// line of the file is new, e.g. for untracked files.
+type LineSet map[int]bool
+
+// Changes maps the absolute paths of changed files to their changed lines.
+type Changes struct {
+	files map[string]LineSet
+}
+
+func (c *Changes) Files() []string {
+	files := make([]string, 0, len(c.files))
+	for f := range c.files {
+		files = append(files, f)
+	}
+	sort.Strings(files)
+	return files
+}
+
+func (c *Changes) Contains(path string) bool {
+	_, ok := c.files[absPath(path)]
+	return ok
+}
+
+// LineChanged reports whether line of path was added or modified.
+func (c *Changes) LineChanged(path string, line int) bool {
+	lines, ok := c.files[absPath(path)]
+	if !ok {
+		return false
+	}
+	return lines == nil || lines[line]
+}
+
+// absPath resolves path the way git reports the top level, so paths under
+// a symlinked directory still match.
+func absPath(path string) string {
+	abs, err := filepath.Abs(path)
+	if err != nil {
+		return path
+	}
+	if real, err := filepath.EvalSymlinks(abs); err == nil {
+		return real
+	}
+	return abs
+}
+
+// Changed collects files changed in the working tree of the repository
+// containing dir, including untracked files. With since set, changes are
+// taken relative to the merge base of since and HEAD, so commits on the
+// current branch are included too.
+func Changed(dir, since string) (*Changes, error) {
+	root, err := TopLevel(dir)
+	if err != nil {
+		return nil, err
+	}
+
+	base := "HEAD"
+	if since != "" {
+		base = since
+		if mb, err := run(root, "merge-base", since, "HEAD"); err == nil {
+			base = strings.TrimSpace(string(mb))
+		}
+	}
+
+	c, err := diff(root, base)
+	if err != nil {
+		return nil, err
+	}
+
+	out, err := run(root, "ls-files", "--others", "--exclude-standard", "-z")
+	if err != nil {
+		return nil, err
+	}
+	for _, name := range splitNUL(out) {
+		c.files[filepath.Join(root, filepath.FromSlash(name))] = nil
+	}
+	return c, nil
+}
+
+// Staged collects files staged in the index of the repository containing
+// dir, with lines changed relative to HEAD.
+func Staged(dir string) (*Changes, error) {
+	root, err := TopLevel(dir)
+	if err != nil {
+		return nil, err
+	}
+	return diff(root, "--cached")
+}
+
+// TopLevel returns the root of the working tree containing dir.
+func TopLevel(dir string) (string, error) {
+	out, err := run(dir, "rev-parse", "--show-toplevel")
+	if err != nil {
+		return "", err
+	}
+	return filepath.FromSlash(strings.TrimSpace(string(out))), nil
+}
+
+// HooksDir returns the directory git runs hooks from, honouring
+// core.hooksPath.
+func HooksDir(dir string) (string, error) {
+	out, err := run(dir, "rev-parse", "--path-format=absolute", "--git-path", "hooks")
+	if err != nil {
+		return "", err
+	}
+	return filepath.FromSlash(strings.TrimSpace(string(out))), nil
+}
+
+// StagedContent returns the content of path as staged in the index, which
+// may differ from the working tree when a file is partially staged.
+func StagedContent(root, path string) ([]byte, error) {
+	rel, err := repoPath(root, path)
+	if err != nil {
+		return nil, err
+	}
+	return run(root, "show", ":"+rel)
+}
+
+// Stage writes content to the index as the staged version of path, keeping
+// its file mode and leaving the working tree untouched.
+func Stage(root, path string, content []byte) error {
+	rel, err := repoPath(root, path)
+	if err != nil {
+		return err
+	}
+
+	entry, err := run(root, "ls-files", "--stage", "-z", "--", rel)
+	if err != nil {
+		return err
+	}
+	fields := strings.Fields(string(entry))
+	if len(fields) < 1 {
+		return fmt.Errorf("%s is not staged", rel)
+	}
+	mode := fields[0]
+
+	cmd := exec.Command("git", "hash-object", "-w", "--stdin", "--no-filters")
+	cmd.Dir = root
+	cmd.Stdin = bytes.NewReader(content)
+	sha, err := cmd.Output()
+	if err != nil {
+		return fmt.Errorf("git hash-object %s: %w", rel, err)
+	}
+
+	_, err = run(root, "update-index", "--cacheinfo", mode+","+strings.TrimSpace(string(sha))+","+rel)
+	return err
+}
+
+func repoPath(root, path string) (string, error) {
+	rel, err := filepath.Rel(absPath(root), absPath(path))
+	if err != nil {
+		return "", err
+	}
+	return filepath.ToSlash(rel), nil
+}
+
+func diff(root, base string) (*Changes, error) {
+	c := &Changes{files: make(map[string]LineSet)}
+
+	names, err := run(root, "diff", "--name-only", "-z", "--no-renames", "--diff-filter=AM", base)
+	if err != nil {
+		return nil, err
+	}
+	for _, name := range splitNUL(names) {
+		c.files[filepath.Join(root, filepath.FromSlash(name))] = LineSet{}
+	}
+
+	patch, err := run(root, "diff", "-U0", "--no-color", "--no-ext-diff", "--no-renames", "--diff-filter=AM", base)
+	if err != nil {
+		return nil, err
+	}
+	for name, lines := range parseDiff(patch) {
+		path := filepath.Join(root, filepath.FromSlash(name))
+		if _, ok := c.files[path]; ok {
+			c.files[path] = lines
+		}
+	}
+	return c, nil
+}
+
+// parseDiff extracts the added line numbers per file from a -U0 patch.
+func parseDiff(patch []byte) map[string]LineSet {
+	files := make(map[string]LineSet)
+	var current LineSet
+
+	scanner := bufio.NewScanner(bytes.NewReader(patch))
+	scanner.Buffer(make([]byte, 0, 64*1024), 16*1024*1024)
+	for scanner.Scan() {
+		line := scanner.Text()
+		switch {
+		case strings.HasPrefix(line, "+++ "):
+			name := strings.TrimPrefix(line, "+++ ")
+			if unquoted, err := strconv.Unquote(name); err == nil {
+				name = unquoted
+			}
+			if !strings.HasPrefix(name, "b/") {
+				current = nil
+				continue
+			}
+			current = LineSet{}
+			files[strings.TrimPrefix(name, "b/")] = current
+		case strings.HasPrefix(line, "@@ ") && current != nil:
+			start, count, ok := parseHunkHeader(line)
+			if !ok {
+				continue
+			}
+			for i := 0; i < count; i++ {
+				current[start+i] = true
+			}
+		}
+	}
+	return files
+}
+
+// parseHunkHeader returns the new-file range of a "@@ -a,b +c,d @@" header.
+func parseHunkHeader(line string) (start, count int, ok bool) {
+	fields := strings.Fields(line)
+	if len(fields) < 3 || !strings.HasPrefix(fields[2], "+") {
+		return 0, 0, false
+	}
+	rng := strings.TrimPrefix(fields[2], "+")
+	count = 1
+	if s, c, found := strings.Cut(rng, ","); found {
+		rng = s
+		n, err := strconv.Atoi(c)
+		if err != nil {
+			return 0, 0, false
+		}
+		count = n
+	}
+	start, err := strconv.Atoi(rng)
+	if err != nil {
+		return 0, 0, false
+	}
+	return start, count, true
+}
+
+func splitNUL(out []byte) []string {
+	var names []string
+	for _, name := range strings.Split(string(out), "\x00") {
+		if name != "" {
+			names = append(names, name)
+		}
+	}
+	return names
+}
+
+func run(dir string, args ...string) ([]byte, error) {
+	args = append([]string{"-c", "core.quotePath=false"}, args...)
+	cmd := exec.Command("git", args...)
+	cmd.Dir = dir
+	var stderr bytes.Buffer
+	cmd.Stderr = &stderr
+	out, err := cmd.Output()
+	if err != nil {
+		msg := strings.TrimSpace(stderr.String())
+		if msg == "" {
+			msg = err.Error()
+		}
+		return nil, fmt.Errorf("git %s: %s", strings.Join(args[2:], " "), msg)
+	}
+	return out, nil
+}
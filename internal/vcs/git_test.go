@@ -0,0 +1,172 @@
+package vcs
+
+import (
+	"os"
+	"os/exec"
+	"path/filepath"
+	"testing"
+)
+
+func TestParseHunkHeader(t *testing.T) {
+	tests := []struct {
+		line        string
+		start, want int
+		ok          bool
+	}{
+		{"@@ -1,2 +3,4 @@", 3, 4, true},
+		{"@@ -1 +7 @@ heading", 7, 1, true},
+		{"@@ -5,3 +4,0 @@", 4, 0, true},
+		{"@@ bogus @@", 0, 0, false},
+	}
+	for _, tt := range tests {
+		start, count, ok := parseHunkHeader(tt.line)
+		if start != tt.start || count != tt.want || ok != tt.ok {
+			t.Errorf("parseHunkHeader(%q) = %d, %d, %v; want %d, %d, %v", tt.line, start, count, ok, tt.start, tt.want, tt.ok)
+		}
+	}
+}
+
+func TestParseDiff(t *testing.T) {
+	patch := `diff --git a/docs/a.md b/docs/a.md
+--- a/docs/a.md
++++ b/docs/a.md
+@@ -2,0 +3,2 @@
++one
++two
+@@ -9 +11 @@
+-old
++new
+diff --git a/gone.md b/gone.md
+--- a/gone.md
++++ /dev/null
+@@ -1 +0,0 @@
+-bye
+diff --git "a/sp ace.md" "b/sp ace.md"
+--- "a/sp ace.md"
++++ "b/sp ace.md"
+@@ -1 +1 @@
+-x
++y
+`
+	files := parseDiff([]byte(patch))
+	if len(files) != 2 {
+		t.Fatalf("parseDiff() = %v, want 2 files", files)
+	}
+	for _, line := range []int{3, 4, 11} {
+		if !files["docs/a.md"][line] {
+			t.Errorf("docs/a.md line %d should be changed", line)
+		}
+	}
+	if files["docs/a.md"][5] {
+		t.Error("docs/a.md line 5 should not be changed")
+	}
+	if !files["sp ace.md"][1] {
+		t.Error("quoted path should be unquoted")
+	}
+}
+
+func git(t *testing.T, dir string, args ...string) {
+	t.Helper()
+	cmd := exec.Command("git", args...)
+	cmd.Dir = dir
+	cmd.Env = append(os.Environ(),
+		"GIT_AUTHOR_NAME=test", "GIT_AUTHOR_EMAIL=test@example.com",
+		"GIT_COMMITTER_NAME=test", "GIT_COMMITTER_EMAIL=test@example.com",
+	)
+	if out, err := cmd.CombinedOutput(); err != nil {
+		t.Fatalf("git %v: %v\n%s", args, err, out)
+	}
+}
+
+func write(t *testing.T, dir, name, content string) {
+	t.Helper()
+	if err := os.WriteFile(filepath.Join(dir, name), []byte(content), 0644); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestChangedAndStaged(t *testing.T) {
+	if _, err := exec.LookPath("git"); err != nil {
+		t.Skip("git not installed")
+	}
+
+	dir := t.TempDir()
+	git(t, dir, "init", "-q", "-b", "main")
+	write(t, dir, "a.md", "one\ntwo\n")
+	write(t, dir, "b.md", "one\n")
+	git(t, dir, "add", ".")
+	git(t, dir, "commit", "-q", "-m", "init")
+
+	git(t, dir, "checkout", "-q", "-b", "feature")
+	write(t, dir, "a.md", "one\ntwo\nthree\n")
+	git(t, dir, "commit", "-q", "-am", "feature")
+	write(t, dir, "b.md", "uno\n")
+	write(t, dir, "new.md", "fresh\n")
+
+	changes, err := Changed(dir, "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if changes.Contains(filepath.Join(dir, "a.md")) {
+		t.Error("a.md is committed and should not count as changed against HEAD")
+	}
+	if !changes.LineChanged(filepath.Join(dir, "b.md"), 1) {
+		t.Error("b.md line 1 should be changed")
+	}
+	if !changes.LineChanged(filepath.Join(dir, "new.md"), 42) {
+		t.Error("untracked files should count as fully changed")
+	}
+
+	changes, err = Changed(dir, "main")
+	if err != nil {
+		t.Fatal(err)
+	}
+	a := filepath.Join(dir, "a.md")
+	if !changes.LineChanged(a, 3) || changes.LineChanged(a, 1) {
+		t.Error("--since main should report only a.md line 3")
+	}
+
+	git(t, dir, "add", "new.md")
+	changes, err = Staged(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if files := changes.Files(); len(files) != 1 || filepath.Base(files[0]) != "new.md" {
+		t.Errorf("Staged() files = %v, want [new.md]", files)
+	}
+}
+
+func TestStageKeepsWorkingTree(t *testing.T) {
+	if _, err := exec.LookPath("git"); err != nil {
+		t.Skip("git not installed")
+	}
+
+	dir := t.TempDir()
+	git(t, dir, "init", "-q")
+	path := filepath.Join(dir, "a.md")
+	write(t, dir, "a.md", "staged  \n")
+	git(t, dir, "add", "a.md")
+	write(t, dir, "a.md", "staged  \nunstaged\n")
+
+	staged, err := StagedContent(dir, path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(staged) != "staged  \n" {
+		t.Errorf("StagedContent() = %q, want the index version", staged)
+	}
+
+	if err := Stage(dir, path, []byte("staged\n")); err != nil {
+		t.Fatal(err)
+	}
+	staged, err = StagedContent(dir, path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(staged) != "staged\n" {
+		t.Errorf("StagedContent() after Stage = %q", staged)
+	}
+	if data, _ := os.ReadFile(path); string(data) != "staged  \nunstaged\n" {
+		t.Errorf("working tree changed to %q", data)
+	}
+}
@@ -0,0 +1,256 @@
+package walker
+
+import (
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+
+	"github.com/bmatcuk/doublestar/v4"
+)
+
+const (
+	gitignoreFile    = ".gitignore"
+	mdmendignoreFile = ".mdmendignore"
+)
+
+// ignoreRule is one parsed gitignore pattern. base is the slash-separated
+// absolute directory the pattern is relative to; it is empty for patterns
+// that match at any depth regardless of where they were declared.
+type ignoreRule struct {
+	pattern  string
+	base     string
+	negate   bool
+	dirOnly  bool
+	anchored bool
+}
+
+func parseIgnoreRule(line, base string) (ignoreRule, bool) {
+	line = strings.TrimSuffix(line, "\r")
+	line = trimTrailingSpaces(line)
+	if line == "" || strings.HasPrefix(line, "#") {
+		return ignoreRule{}, false
+	}
+
+	rule := ignoreRule{base: base}
+	if strings.HasPrefix(line, "!") {
+		rule.negate = true
+		line = line[1:]
+	} else if strings.HasPrefix(line, `\!`) || strings.HasPrefix(line, `\#`) {
+		line = line[1:]
+	}
+
+	if strings.HasSuffix(line, "/") {
+		rule.dirOnly = true
+		line = strings.TrimRight(line, "/")
+	}
+	if strings.Contains(line, "/") {
+		rule.anchored = true
+		line = strings.TrimPrefix(line, "/")
+	}
+	if line == "" {
+		return ignoreRule{}, false
+	}
+
+	rule.pattern = line
+	return rule, true
+}
+
+// trimTrailingSpaces drops trailing spaces unless they are escaped with a
+// backslash, as git does.
+func trimTrailingSpaces(line string) string {
+	end := len(line)
+	for end > 0 && line[end-1] == ' ' {
+		if end > 1 && line[end-2] == '\\' {
+			break
+		}
+		end--
+	}
+	return line[:end]
+}
+
+func parseIgnoreFile(content, base string) []ignoreRule {
+	var rules []ignoreRule
+	for _, line := range strings.Split(content, "\n") {
+		if rule, ok := parseIgnoreRule(line, base); ok {
+			rules = append(rules, rule)
+		}
+	}
+	return rules
+}
+
+// match reports whether the rule applies to p, a slash-separated absolute
+// path.
+func (r ignoreRule) match(p string, isDir bool) bool {
+	if r.dirOnly && !isDir {
+		return false
+	}
+
+	rel := p
+	if r.base != "" {
+		prefix := strings.TrimSuffix(r.base, "/") + "/"
+		if !strings.HasPrefix(p, prefix) {
+			return false
+		}
+		rel = p[len(prefix):]
+	}
+
+	if r.anchored {
+		ok, _ := doublestar.Match(r.pattern, rel)
+		return ok
+	}
+	ok, _ := doublestar.Match(r.pattern, path.Base(rel))
+	return ok
+}
+
+// ignoreMatcher applies .gitignore, .mdmendignore and .git/info/exclude
+// files from root downwards, followed by the configured ignore patterns.
+// Like git, a later matching rule overrides an earlier one, deeper ignore
+// files override shallower ones, and nothing inside an ignored directory
+// can be re-included. root is the repository root when there is one; start
+// is the directory being walked, which is never ignored itself.
+type ignoreMatcher struct {
+	root      string
+	start     string
+	files     []string
+	exclude   []ignoreRule
+	patterns  []ignoreRule
+	dirRules  map[string][]ignoreRule
+	dirIgnore map[string]bool
+}
+
+func newIgnoreMatcher(start string, patterns []string, ignoreFiles, gitignore bool) *ignoreMatcher {
+	abs, err := filepath.Abs(start)
+	if err != nil {
+		abs = start
+	}
+	cwd, err := os.Getwd()
+	if err != nil {
+		cwd = abs
+	}
+
+	root := abs
+	if repo := findRepoRoot(abs); repo != "" {
+		root = repo
+	} else if isUnder(filepath.ToSlash(abs), filepath.ToSlash(cwd)) {
+		root = cwd
+	}
+
+	m := &ignoreMatcher{
+		root:      filepath.ToSlash(root),
+		start:     filepath.ToSlash(abs),
+		dirRules:  make(map[string][]ignoreRule),
+		dirIgnore: make(map[string]bool),
+	}
+
+	if ignoreFiles {
+		if gitignore {
+			m.files = append(m.files, gitignoreFile)
+			if data, err := os.ReadFile(filepath.Join(root, ".git", "info", "exclude")); err == nil {
+				m.exclude = parseIgnoreFile(string(data), m.root)
+			}
+		}
+		m.files = append(m.files, mdmendignoreFile)
+	}
+
+	for _, p := range patterns {
+		rule, ok := parseIgnoreRule(p, filepath.ToSlash(cwd))
+		if !ok {
+			continue
+		}
+		// A leading **/ matches at any depth, so like an unanchored
+		// pattern it also applies to paths outside the working directory.
+		if !rule.anchored || strings.HasPrefix(rule.pattern, "**/") {
+			rule.base = ""
+		}
+		m.patterns = append(m.patterns, rule)
+	}
+
+	return m
+}
+
+func findRepoRoot(dir string) string {
+	for {
+		if _, err := os.Stat(filepath.Join(dir, ".git")); err == nil {
+			return dir
+		}
+		parent := filepath.Dir(dir)
+		if parent == dir {
+			return ""
+		}
+		dir = parent
+	}
+}
+
+func (m *ignoreMatcher) rulesFor(dir string) []ignoreRule {
+	if rules, ok := m.dirRules[dir]; ok {
+		return rules
+	}
+	var rules []ignoreRule
+	for _, name := range m.files {
+		data, err := os.ReadFile(filepath.Join(filepath.FromSlash(dir), name))
+		if err != nil {
+			continue
+		}
+		rules = append(rules, parseIgnoreFile(string(data), dir)...)
+	}
+	m.dirRules[dir] = rules
+	return rules
+}
+
+func isUnder(p, dir string) bool {
+	return p == dir || strings.HasPrefix(p, strings.TrimSuffix(dir, "/")+"/")
+}
+
+// Ignored reports whether p is excluded, either directly or because one of
+// its parent directories below start is.
+func (m *ignoreMatcher) Ignored(p string, isDir bool) bool {
+	abs, err := filepath.Abs(p)
+	if err != nil {
+		return false
+	}
+	abs = filepath.ToSlash(abs)
+	if abs == m.start || !isUnder(abs, m.start) {
+		return false
+	}
+	if m.dirIgnored(path.Dir(abs)) {
+		return true
+	}
+	return m.evaluate(abs, isDir)
+}
+
+func (m *ignoreMatcher) dirIgnored(dir string) bool {
+	if dir == m.start || !isUnder(dir, m.start) {
+		return false
+	}
+	if ignored, ok := m.dirIgnore[dir]; ok {
+		return ignored
+	}
+	ignored := m.dirIgnored(path.Dir(dir)) || m.evaluate(dir, true)
+	m.dirIgnore[dir] = ignored
+	return ignored
+}
+
+// evaluate applies every rule that can affect p, in precedence order, and
+// returns the outcome of the last one that matches.
+func (m *ignoreMatcher) evaluate(p string, isDir bool) bool {
+	ignored := false
+	apply := func(rules []ignoreRule) {
+		for _, r := range rules {
+			if r.match(p, isDir) {
+				ignored = !r.negate
+			}
+		}
+	}
+
+	apply(m.exclude)
+	dir := m.root
+	rel := strings.TrimPrefix(p, strings.TrimSuffix(m.root, "/")+"/")
+	parts := strings.Split(rel, "/")
+	for i := 0; i < len(parts); i++ {
+		apply(m.rulesFor(dir))
+		dir = path.Join(dir, parts[i])
+	}
+	apply(m.patterns)
+	return ignored
+}
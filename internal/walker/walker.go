@@ -1,28 +1,176 @@
 package walker
 
 import (
+	"bytes"
+	"io"
 	"os"
 	"path/filepath"
 	"strings"
+	"unicode/utf8"
 
 	"github.com/bmatcuk/doublestar/v4"
 )
 
 type Walker struct {
-	patterns []string
-	ignores  []string
+	patterns         []string
+	ignores          []string
+	extensions       []string
+	ignoreFiles      bool
+	respectGitignore bool
+	filter           func(path string) bool
+	followSymlinks   bool
+	maxDepth         int
+	maxFileSize      int64
+	skipBinary       bool
+	allowInvalidUTF8 bool
+	skipped          []Skipped
 }
 
-func New(ignores []string) *Walker {
-	return &Walker{
-		patterns: []string{},
-		ignores:  ignores,
+// Reasons reported in Skipped.
+const (
+	SkipTooLarge = "larger than max file size"
+	SkipBinary   = "binary"
+	SkipNotUTF8  = "not valid UTF-8"
+	SkipMaxDepth = "deeper than max depth"
+	SkipVisited  = "symlink cycle or already visited"
+)
+
+// Skipped records a file or directory left out of a walk and why.
+type Skipped struct {
+	Path   string
+	Reason string
+}
+
+type Option func(*Walker)
+
+// WithIgnoreFiles controls whether .gitignore, .mdmendignore and
+// .git/info/exclude are read. Configured ignore patterns always apply.
+func WithIgnoreFiles(enabled bool) Option {
+	return func(w *Walker) {
+		w.ignoreFiles = enabled
+	}
+}
+
+// WithGitignore controls whether .gitignore and .git/info/exclude are read;
+// .mdmendignore files are still honoured when it is disabled.
+func WithGitignore(enabled bool) Option {
+	return func(w *Walker) {
+		w.respectGitignore = enabled
+	}
+}
+
+// WithExtensions sets the file extensions treated as Markdown, e.g. ".md"
+// and ".mdx". Only ".md" files are walked by default.
+func WithExtensions(exts ...string) Option {
+	return func(w *Walker) {
+		w.extensions = nil
+		for _, ext := range exts {
+			w.extensions = append(w.extensions, strings.ToLower(ext))
+		}
+	}
+}
+
+// WithInclude adds glob patterns for files to walk regardless of their
+// extension, e.g. "*.md.tmpl". Ignore rules still apply to them.
+func WithInclude(patterns ...string) Option {
+	return func(w *Walker) {
+		w.patterns = append(w.patterns, patterns...)
 	}
 }
 
+// WithFilter restricts the walk to files for which keep returns true, e.g.
+// files changed in git. Ignore rules and extensions are applied first.
+func WithFilter(keep func(path string) bool) Option {
+	return func(w *Walker) {
+		w.filter = keep
+	}
+}
+
+// WithFollowSymlinks makes the walker descend into symlinked directories.
+// Each real directory is visited once, so symlink cycles terminate.
+func WithFollowSymlinks(enabled bool) Option {
+	return func(w *Walker) {
+		w.followSymlinks = enabled
+	}
+}
+
+// WithMaxDepth limits how deep directory walks go: 1 only returns files
+// directly inside the walked directory. 0 means unlimited.
+func WithMaxDepth(depth int) Option {
+	return func(w *Walker) {
+		w.maxDepth = depth
+	}
+}
+
+// WithMaxFileSize skips files larger than size bytes. 0 means unlimited.
+func WithMaxFileSize(size int64) Option {
+	return func(w *Walker) {
+		w.maxFileSize = size
+	}
+}
+
+// WithSkipBinary controls whether binary and non-UTF-8 files are skipped.
+// Enabled by default.
+func WithSkipBinary(enabled bool) Option {
+	return func(w *Walker) {
+		w.skipBinary = enabled
+	}
+}
+
+// WithAllowInvalidUTF8 keeps files that are not valid UTF-8 when binary
+// files are skipped, for fixes that may write them back.
+func WithAllowInvalidUTF8(allow bool) Option {
+	return func(w *Walker) {
+		w.allowInvalidUTF8 = allow
+	}
+}
+
+func New(ignores []string, opts ...Option) *Walker {
+	w := &Walker{
+		patterns:         []string{},
+		ignores:          ignores,
+		extensions:       []string{".md"},
+		ignoreFiles:      true,
+		respectGitignore: true,
+		skipBinary:       true,
+	}
+	for _, opt := range opts {
+		opt(w)
+	}
+	return w
+}
+
+func (w *Walker) newMatcher(start string) *ignoreMatcher {
+	return newIgnoreMatcher(start, w.ignores, w.ignoreFiles, w.respectGitignore)
+}
+
+// Includes reports whether path is a Markdown file the walker would return
+// when walking the repository, or the working directory, that contains it.
+// Ignore rules apply to every parent directory up to that root, so a file
+// inside an ignored directory is excluded.
+func (w *Walker) Includes(path string) bool {
+	if !w.isMarkdown(path) {
+		return false
+	}
+	matcher := w.newMatcher(filepath.Dir(path))
+	matcher.start = matcher.root
+	return !matcher.Ignored(path, false)
+}
+
+// Skipped returns the files and directories the last Walk left out because
+// of size, content, depth or symlink limits.
+func (w *Walker) Skipped() []Skipped {
+	return w.skipped
+}
+
+func (w *Walker) skip(path, reason string) {
+	w.skipped = append(w.skipped, Skipped{Path: path, Reason: reason})
+}
+
 func (w *Walker) Walk(paths []string) ([]string, error) {
 	var files []string
 	seen := make(map[string]bool)
+	w.skipped = nil
 
 	for _, path := range paths {
 		matches, err := w.expandPath(path)
@@ -30,6 +178,9 @@ func (w *Walker) Walk(paths []string) ([]string, error) {
 			return nil, err
 		}
 		for _, m := range matches {
+			if w.filter != nil && !w.filter(m) {
+				continue
+			}
 			if !seen[m] {
 				seen[m] = true
 				files = append(files, m)
@@ -49,8 +200,9 @@ func (w *Walker) expandPath(path string) ([]string, error) {
 		if err != nil {
 			return nil, err
 		}
+		matcher := w.newMatcher(base)
 		for _, m := range globMatches {
-			if w.shouldInclude(m) {
+			if w.isMarkdown(m) && !matcher.Ignored(m, false) && w.admit(m, nil) {
 				files = append(files, m)
 			}
 		}
@@ -63,42 +215,172 @@ func (w *Walker) expandPath(path string) ([]string, error) {
 	}
 
 	if !info.IsDir() {
-		if w.shouldInclude(path) {
+		if w.isMarkdown(path) && !w.ignoredExplicit(path) && w.admit(path, info) {
 			return []string{path}, nil
 		}
 		return []string{}, nil
 	}
 
-	err = filepath.Walk(path, func(p string, info os.FileInfo, err error) error {
+	visited := make(map[string]bool)
+	if real, err := filepath.EvalSymlinks(path); err == nil {
+		visited[real] = true
+	}
+	err = w.walkDir(path, 1, w.newMatcher(path), visited, &files)
+	return files, err
+}
+
+// walkDir collects the files below dir in lexical order. depth is the depth
+// of dir's entries relative to the walk root.
+func (w *Walker) walkDir(dir string, depth int, matcher *ignoreMatcher, visited map[string]bool, files *[]string) error {
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return err
+	}
+
+	for _, entry := range entries {
+		p := filepath.Join(dir, entry.Name())
+
+		info, err := entry.Info()
 		if err != nil {
 			return err
 		}
-		if !info.IsDir() && w.shouldInclude(p) {
-			files = append(files, p)
+		if info.Mode()&os.ModeSymlink != 0 {
+			target, err := os.Stat(p)
+			if err != nil {
+				continue
+			}
+			if target.IsDir() && !w.followSymlinks {
+				continue
+			}
+			info = target
 		}
-		return nil
-	})
 
-	return files, err
+		if !info.IsDir() {
+			if w.maxDepth > 0 && depth > w.maxDepth {
+				continue
+			}
+			if w.isMarkdown(p) && !matcher.Ignored(p, false) && w.admit(p, info) {
+				*files = append(*files, p)
+			}
+			continue
+		}
+
+		if entry.Name() == ".git" || matcher.Ignored(p, true) {
+			continue
+		}
+		if w.maxDepth > 0 && depth >= w.maxDepth {
+			w.skip(p, SkipMaxDepth)
+			continue
+		}
+		real, err := filepath.EvalSymlinks(p)
+		if err != nil {
+			continue
+		}
+		if visited[real] {
+			w.skip(p, SkipVisited)
+			continue
+		}
+		visited[real] = true
+		if err := w.walkDir(p, depth+1, matcher, visited, files); err != nil {
+			return err
+		}
+	}
+	return nil
 }
 
-func (w *Walker) shouldInclude(path string) bool {
-	if !strings.HasSuffix(strings.ToLower(path), ".md") {
+// admit applies the size and content limits to a Markdown file, recording
+// why it was skipped. info may be nil.
+func (w *Walker) admit(path string, info os.FileInfo) bool {
+	if w.maxFileSize <= 0 && !w.skipBinary {
+		return true
+	}
+	if info == nil {
+		var err error
+		if info, err = os.Stat(path); err != nil {
+			return true
+		}
+	}
+	if w.maxFileSize > 0 && info.Size() > w.maxFileSize {
+		w.skip(path, SkipTooLarge)
 		return false
 	}
-
-	for _, ignore := range w.ignores {
-		matched, _ := doublestar.Match(ignore, path)
-		if matched {
+	if w.skipBinary {
+		if reason := sniffContent(path); reason != "" && !(reason == SkipNotUTF8 && w.allowInvalidUTF8) {
+			w.skip(path, reason)
 			return false
 		}
-		matched, _ = doublestar.Match(ignore, filepath.Base(path))
-		if matched {
-			return false
+	}
+	return true
+}
+
+const sniffLen = 8000
+
+// sniffContent returns SkipBinary when the start of the file contains a NUL
+// byte, SkipNotUTF8 when it does not decode, and "" otherwise.
+func sniffContent(path string) string {
+	f, err := os.Open(path)
+	if err != nil {
+		return ""
+	}
+	defer func() { _ = f.Close() }()
+
+	buf := make([]byte, sniffLen)
+	n, _ := io.ReadFull(f, buf)
+	buf = buf[:n]
+
+	if bytes.IndexByte(buf, 0) >= 0 {
+		return SkipBinary
+	}
+	if n == sniffLen {
+		buf = trimPartialRune(buf)
+	}
+	if !utf8.Valid(buf) {
+		return SkipNotUTF8
+	}
+	return ""
+}
+
+// trimPartialRune drops a multi-byte rune cut off at the end of b.
+func trimPartialRune(b []byte) []byte {
+	for i := 1; i < utf8.UTFMax && i <= len(b); i++ {
+		if utf8.RuneStart(b[len(b)-i]) {
+			if !utf8.FullRune(b[len(b)-i:]) {
+				return b[:len(b)-i]
+			}
+			return b
 		}
 	}
+	return b
+}
 
-	return true
+// ignoredExplicit reports whether a file named on the command line is
+// excluded. Only the ignore patterns apply to it, not ignore files.
+func (w *Walker) ignoredExplicit(path string) bool {
+	matcher := newIgnoreMatcher(filepath.Dir(path), w.ignores, false, false)
+	matcher.start = matcher.root
+	return matcher.Ignored(path, false)
+}
+
+func (w *Walker) isMarkdown(path string) bool {
+	lower := strings.ToLower(path)
+	for _, ext := range w.extensions {
+		if strings.HasSuffix(lower, ext) {
+			return true
+		}
+	}
+	slashPath := filepath.ToSlash(path)
+	for _, pattern := range w.patterns {
+		if matched, _ := doublestar.Match(pattern, slashPath); matched {
+			return true
+		}
+		if matched, _ := doublestar.Match("**/"+strings.TrimPrefix(pattern, "/"), slashPath); matched {
+			return true
+		}
+		if matched, _ := doublestar.Match(pattern, filepath.Base(path)); matched {
+			return true
+		}
+	}
+	return false
 }
 
 func splitGlob(path string) (string, string) {
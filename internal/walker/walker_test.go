@@ -3,6 +3,7 @@ package walker
 import (
 	"os"
 	"path/filepath"
+	"strings"
 	"testing"
 )
 
@@ -245,7 +246,7 @@ func TestWalkerDedup(t *testing.T) {
 	}
 }
 
-func TestShouldIncludeCaseInsensitive(t *testing.T) {
+func TestIsMarkdownCaseInsensitive(t *testing.T) {
 	w := New([]string{})
 
 	tests := []struct {
@@ -260,9 +261,9 @@ func TestShouldIncludeCaseInsensitive(t *testing.T) {
 	}
 
 	for _, tt := range tests {
-		got := w.shouldInclude(tt.path)
+		got := w.isMarkdown(tt.path)
 		if got != tt.want {
-			t.Errorf("shouldInclude(%q) = %v, want %v", tt.path, got, tt.want)
+			t.Errorf("isMarkdown(%q) = %v, want %v", tt.path, got, tt.want)
 		}
 	}
 }
@@ -318,3 +319,333 @@ func TestExpandPathGlobError(t *testing.T) {
 		t.Error("expandPath() should error for invalid glob pattern")
 	}
 }
+
+func writeTree(t *testing.T, root string, files map[string]string) {
+	t.Helper()
+	for name, content := range files {
+		path := filepath.Join(root, filepath.FromSlash(name))
+		if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+}
+
+func walkRelative(t *testing.T, w *Walker, root string) map[string]bool {
+	t.Helper()
+	result, err := w.Walk([]string{root})
+	if err != nil {
+		t.Fatalf("Walk() error = %v", err)
+	}
+	found := make(map[string]bool)
+	for _, p := range result {
+		rel, err := filepath.Rel(root, p)
+		if err != nil {
+			t.Fatal(err)
+		}
+		found[filepath.ToSlash(rel)] = true
+	}
+	return found
+}
+
+func TestWalkerGitignore(t *testing.T) {
+	tmpDir := t.TempDir()
+	writeTree(t, tmpDir, map[string]string{
+		".git/info/exclude":      "local.md\n",
+		".gitignore":             "# comment\n*.draft.md\n/build\nvendor/\n!keep.draft.md\n",
+		"README.md":              "",
+		"local.md":               "",
+		"notes.draft.md":         "",
+		"keep.draft.md":          "",
+		"build/out.md":           "",
+		"docs/build/guide.md":    "",
+		"docs/vendor/lib.md":     "",
+		"docs/.gitignore":        "private/\n!/secret.md\n",
+		"docs/private/a.md":      "",
+		"docs/secret.md":         "",
+		"docs/.mdmendignore":     "generated.md\n",
+		"docs/generated.md":      "",
+		"docs/sub/generated.md":  "",
+		"vendor/file.md":         "",
+		"vendor/.gitignore":      "!file.md\n",
+		"other/vendor":           "",
+		"other/vendor.md":        "",
+		".git/objects/ignore.md": "",
+	})
+
+	found := walkRelative(t, New(nil), tmpDir)
+	want := []string{
+		"README.md",
+		"keep.draft.md",
+		"docs/build/guide.md",
+		"docs/secret.md",
+		"other/vendor.md",
+	}
+	if len(found) != len(want) {
+		t.Errorf("Walk() found %v, want %v", found, want)
+	}
+	for _, name := range want {
+		if !found[name] {
+			t.Errorf("Walk() missing %s (found %v)", name, found)
+		}
+	}
+}
+
+func TestWalkerIncludes(t *testing.T) {
+	tmpDir := t.TempDir()
+	writeTree(t, tmpDir, map[string]string{
+		".git/info/exclude":    "",
+		".mdmendignore":        "generated/\n",
+		"docs/.gitignore":      "drafts/\n",
+		"README.md":            "",
+		"generated/a.md":       "",
+		"generated/deep/b.md":  "",
+		"docs/guide.md":        "",
+		"docs/drafts/wip.md":   "",
+		"docs/drafts/notes.md": "",
+	})
+
+	w := New(nil)
+	for name, want := range map[string]bool{
+		"README.md":            true,
+		"docs/guide.md":        true,
+		"generated/a.md":       false,
+		"generated/deep/b.md":  false,
+		"docs/drafts/wip.md":   false,
+		"docs/drafts/notes.md": false,
+	} {
+		if got := w.Includes(filepath.Join(tmpDir, filepath.FromSlash(name))); got != want {
+			t.Errorf("Includes(%s) = %v, want %v", name, got, want)
+		}
+	}
+}
+
+func TestWalkerIgnoreExplicitFiles(t *testing.T) {
+	tmpDir := t.TempDir()
+	writeTree(t, tmpDir, map[string]string{
+		".mdmendignore": "kept.md\n",
+		"kept.md":       "",
+		"skip.md":       "",
+	})
+
+	w := New([]string{"skip.md"})
+	var paths []string
+	for _, name := range []string{"kept.md", "skip.md"} {
+		paths = append(paths, filepath.Join(tmpDir, filepath.FromSlash(name)))
+	}
+	found, err := w.Walk(paths)
+	if err != nil {
+		t.Fatalf("Walk() error = %v", err)
+	}
+	if len(found) != 1 || filepath.Base(found[0]) != "kept.md" {
+		t.Errorf("Walk() = %v, want only kept.md, which ignore files do not exclude when named", found)
+	}
+}
+
+func TestWalkerIgnoreFileSwitches(t *testing.T) {
+	tmpDir := t.TempDir()
+	writeTree(t, tmpDir, map[string]string{
+		".gitignore":    "git.md\n",
+		".mdmendignore": "mdmend.md\n",
+		"git.md":        "",
+		"mdmend.md":     "",
+		"config.md":     "",
+		"kept.md":       "",
+	})
+
+	tests := []struct {
+		name string
+		opts []Option
+		want []string
+	}{
+		{"default", nil, []string{"kept.md"}},
+		{"no gitignore", []Option{WithGitignore(false)}, []string{"kept.md", "git.md"}},
+		{"no ignore files", []Option{WithIgnoreFiles(false)}, []string{"kept.md", "git.md", "mdmend.md"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			found := walkRelative(t, New([]string{"config.md"}, tt.opts...), tmpDir)
+			if len(found) != len(tt.want) {
+				t.Errorf("Walk() found %v, want %v", found, tt.want)
+			}
+			for _, name := range tt.want {
+				if !found[name] {
+					t.Errorf("Walk() missing %s", name)
+				}
+			}
+		})
+	}
+}
+
+func TestWalkerConfigIgnoreDirectory(t *testing.T) {
+	tmpDir := t.TempDir()
+	writeTree(t, tmpDir, map[string]string{
+		"README.md":               "",
+		"node_modules/pkg/doc.md": "",
+	})
+
+	found := walkRelative(t, New([]string{"node_modules/"}), tmpDir)
+	if len(found) != 1 || !found["README.md"] {
+		t.Errorf("Walk() found %v, want only README.md", found)
+	}
+}
+
+func TestWalkerExtensions(t *testing.T) {
+	tmpDir := t.TempDir()
+	writeTree(t, tmpDir, map[string]string{
+		"README.md":          "",
+		"guide.markdown":     "",
+		"Button.mdx":         "",
+		"notes.txt":          "",
+		"docs/CHANGES":       "",
+		"docs/other":         "",
+		"docs/page.MARKDOWN": "",
+	})
+
+	tests := []struct {
+		name string
+		opts []Option
+		want []string
+	}{
+		{"default", nil, []string{"README.md"}},
+		{"extensions", []Option{WithExtensions(".md", ".MARKDOWN", ".mdx")}, []string{"README.md", "guide.markdown", "Button.mdx", "docs/page.MARKDOWN"}},
+		{"include", []Option{WithInclude("docs/CHANGES", "*.txt")}, []string{"README.md", "notes.txt", "docs/CHANGES"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			found := walkRelative(t, New(nil, tt.opts...), tmpDir)
+			if len(found) != len(tt.want) {
+				t.Errorf("Walk() found %v, want %v", found, tt.want)
+			}
+			for _, name := range tt.want {
+				if !found[name] {
+					t.Errorf("Walk() missing %s", name)
+				}
+			}
+		})
+	}
+}
+
+func TestWalkerFilter(t *testing.T) {
+	tmpDir := t.TempDir()
+	writeTree(t, tmpDir, map[string]string{
+		"changed.md":      "",
+		"unchanged.md":    "",
+		"docs/changed.md": "",
+	})
+
+	keep := func(path string) bool {
+		return filepath.Base(path) == "changed.md"
+	}
+	found := walkRelative(t, New(nil, WithFilter(keep)), tmpDir)
+	if len(found) != 2 || !found["changed.md"] || !found["docs/changed.md"] {
+		t.Errorf("Walk() found %v, want changed.md and docs/changed.md", found)
+	}
+}
+
+func TestWalkerLimits(t *testing.T) {
+	tmpDir := t.TempDir()
+	writeTree(t, tmpDir, map[string]string{
+		"top.md":         "# Top\n",
+		"big.md":         strings.Repeat("x", 2048),
+		"binary.md":      "a\x00b",
+		"latin1.md":      "caf\xe9\n",
+		"utf8.md":        "café\n",
+		"one/mid.md":     "",
+		"one/two/low.md": "",
+	})
+
+	w := New(nil, WithMaxDepth(2), WithMaxFileSize(1024))
+	found := walkRelative(t, w, tmpDir)
+	want := []string{"top.md", "utf8.md", "one/mid.md"}
+	if len(found) != len(want) {
+		t.Errorf("Walk() found %v, want %v", found, want)
+	}
+	for _, name := range want {
+		if !found[name] {
+			t.Errorf("Walk() missing %s", name)
+		}
+	}
+
+	reasons := make(map[string]string)
+	for _, s := range w.Skipped() {
+		rel, _ := filepath.Rel(tmpDir, s.Path)
+		reasons[filepath.ToSlash(rel)] = s.Reason
+	}
+	wantReasons := map[string]string{
+		"big.md":    SkipTooLarge,
+		"binary.md": SkipBinary,
+		"latin1.md": SkipNotUTF8,
+		"one/two":   SkipMaxDepth,
+	}
+	for path, reason := range wantReasons {
+		if reasons[path] != reason {
+			t.Errorf("Skipped()[%s] = %q, want %q", path, reasons[path], reason)
+		}
+	}
+
+	found = walkRelative(t, New(nil, WithAllowInvalidUTF8(true)), tmpDir)
+	if !found["latin1.md"] || found["binary.md"] {
+		t.Errorf("Walk() allowing invalid UTF-8 found %v", found)
+	}
+
+	found = walkRelative(t, New(nil, WithSkipBinary(false)), tmpDir)
+	if !found["binary.md"] || !found["latin1.md"] || !found["one/two/low.md"] {
+		t.Errorf("Walk() without limits found %v", found)
+	}
+}
+
+func TestWalkerFollowSymlinks(t *testing.T) {
+	tmpDir := t.TempDir()
+	writeTree(t, tmpDir, map[string]string{
+		"docs/guide.md":     "",
+		"shared/common.md":  "",
+		"shared/sub/sub.md": "",
+	})
+	if err := os.Symlink(filepath.Join(tmpDir, "shared"), filepath.Join(tmpDir, "docs", "linked")); err != nil {
+		t.Skipf("symlinks not supported: %v", err)
+	}
+	if err := os.Symlink(tmpDir, filepath.Join(tmpDir, "shared", "sub", "loop")); err != nil {
+		t.Fatal(err)
+	}
+
+	docs := filepath.Join(tmpDir, "docs")
+	found := walkRelative(t, New(nil), docs)
+	if len(found) != 1 || !found["guide.md"] {
+		t.Errorf("Walk() without following found %v, want only guide.md", found)
+	}
+
+	found = walkRelative(t, New(nil, WithFollowSymlinks(true)), docs)
+	for _, name := range []string{"guide.md", "linked/common.md", "linked/sub/sub.md"} {
+		if !found[name] {
+			t.Errorf("Walk() following symlinks missing %s (found %v)", name, found)
+		}
+	}
+	if found["linked/sub/loop/docs/guide.md"] {
+		t.Error("Walk() should not revisit the walk root through a symlink cycle")
+	}
+}
+
+func TestParseIgnoreRule(t *testing.T) {
+	tests := []struct {
+		line string
+		want ignoreRule
+		ok   bool
+	}{
+		{"", ignoreRule{}, false},
+		{"# comment", ignoreRule{}, false},
+		{`\#file.md`, ignoreRule{pattern: "#file.md"}, true},
+		{"!keep.md", ignoreRule{pattern: "keep.md", negate: true}, true},
+		{"/docs", ignoreRule{pattern: "docs", anchored: true}, true},
+		{"build/", ignoreRule{pattern: "build", dirOnly: true}, true},
+		{"docs/*.md  ", ignoreRule{pattern: "docs/*.md", anchored: true}, true},
+	}
+	for _, tt := range tests {
+		got, ok := parseIgnoreRule(tt.line, "")
+		if ok != tt.ok || got != tt.want {
+			t.Errorf("parseIgnoreRule(%q) = %+v, %v; want %+v, %v", tt.line, got, ok, tt.want, tt.ok)
+		}
+	}
+}
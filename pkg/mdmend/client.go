@@ -14,9 +14,11 @@ import (
 )
 
 type Client struct {
-	cfg             *config.Config
-	dryRun          bool
-	ConfigLoadError error
+	cfg              *config.Config
+	dryRun           bool
+	allowInvalidUTF8 bool
+	walkerOpts       []walker.Option
+	ConfigLoadError  error
 }
 
 func NewClient(opts ...Option) *Client {
@@ -37,15 +39,33 @@ func NewClient(opts ...Option) *Client {
 	if len(options.disabled) > 0 {
 		cfg.Disable = append(cfg.Disable, options.disabled...)
 	}
+	if len(options.enabled) > 0 {
+		cfg.Enable = append(cfg.Enable, options.enabled...)
+	}
+	if len(options.selection) > 0 {
+		cfg.Select = append(cfg.Select, options.selection...)
+	}
 	if len(options.ignore) > 0 {
 		cfg.Ignore = append(cfg.Ignore, options.ignore...)
 	}
+	if len(options.extensions) > 0 {
+		cfg.Extensions = options.extensions
+	}
+	if len(options.include) > 0 {
+		cfg.Include = append(cfg.Include, options.include...)
+	}
 	if options.tabSize > 0 {
 		cfg.TabSize = options.tabSize
 	}
 	if options.aggressive != nil {
 		cfg.Aggressive = *options.aggressive
 	}
+	if options.fixLevel != "" {
+		cfg.FixLevel = options.fixLevel
+	}
+	if options.slugStyle != "" {
+		cfg.SlugStyle = options.slugStyle
+	}
 
 	// Apply rule overrides after config is resolved
 	if len(options.ruleOverrides) > 0 {
@@ -57,24 +77,56 @@ func NewClient(opts ...Option) *Client {
 		}
 	}
 
+	if err := applyOverrides(cfg, options); err != nil && loadErr == nil {
+		loadErr = NewConfigError(options.configPath, err)
+	}
+	if _, err := cfg.GetMaxFileSize(); err != nil && loadErr == nil {
+		loadErr = NewConfigError(options.configPath, err)
+	}
+	if _, err := config.ParseFixLevel(cfg.FixLevel); err != nil && loadErr == nil {
+		loadErr = NewConfigError(options.configPath, err)
+	}
+	if _, err := config.ParseSlugStyle(cfg.SlugStyle); err != nil && loadErr == nil {
+		loadErr = NewConfigError(options.configPath, err)
+	}
+
 	dryRun := false
 	if options.dryRun != nil {
 		dryRun = *options.dryRun
 	}
 
 	return &Client{
-		cfg:             cfg,
-		dryRun:          dryRun,
+		cfg:              cfg,
+		dryRun:           dryRun,
+		allowInvalidUTF8: options.allowInvalidUTF8,
+		walkerOpts: []walker.Option{
+			walker.WithAllowInvalidUTF8(options.allowInvalidUTF8),
+			walker.WithIgnoreFiles(!options.noIgnoreFiles),
+			walker.WithGitignore(!options.noGitignore),
+		},
 		ConfigLoadError: loadErr,
 	}
 }
 
+func applyOverrides(cfg *config.Config, options *clientOptions) error {
+	if options.useEnv {
+		environ := options.environ
+		if environ == nil {
+			environ = os.Environ()
+		}
+		if err := config.ApplyEnvOverrides(cfg, environ); err != nil {
+			return err
+		}
+	}
+	return config.ApplyOverrides(cfg, options.overrides)
+}
+
 func (c *Client) Config() *Config {
 	return fromInternalConfig(c.cfg)
 }
 
 func (c *Client) LintString(content, path string) LintResult {
-	l := linter.New(c.cfg)
+	l := linter.New(config.ApplyFlavor(c.cfg, path))
 	result := l.Lint(content, path)
 	return LintResult{
 		Violations: convertViolations(result.Violations),
@@ -97,15 +149,28 @@ func (c *Client) LintFile(path string) (LintResult, error) {
 	return c.LintString(string(content), path), nil
 }
 
+// newWalker builds a walker honouring the configured extensions, include
+// patterns and ignore settings.
+func (c *Client) newWalker() *walker.Walker {
+	maxFileSize, _ := c.cfg.GetMaxFileSize()
+	opts := append([]walker.Option{
+		walker.WithExtensions(c.cfg.GetExtensions()...),
+		walker.WithInclude(c.cfg.Include...),
+		walker.WithFollowSymlinks(c.cfg.FollowSymlinks),
+		walker.WithMaxDepth(c.cfg.MaxDepth),
+		walker.WithMaxFileSize(maxFileSize),
+	}, c.walkerOpts...)
+	return walker.New(c.cfg.Ignore, opts...)
+}
+
 func (c *Client) LintFiles(paths []string) ([]FileResult, error) {
-	w := walker.New(c.cfg.Ignore)
+	w := c.newWalker()
 	files, err := w.Walk(paths)
 	if err != nil {
 		return nil, err
 	}
 
 	results := make([]FileResult, 0, len(files))
-	l := linter.New(c.cfg)
 
 	for _, path := range files {
 		content, err := os.ReadFile(path)
@@ -117,10 +182,10 @@ func (c *Client) LintFiles(paths []string) ([]FileResult, error) {
 			continue
 		}
 
-		lintResult := l.Lint(string(content), path)
+		lintResult := c.LintString(string(content), path)
 		results = append(results, FileResult{
 			Path:       path,
-			Violations: convertViolations(lintResult.Violations),
+			Violations: lintResult.Violations,
 		})
 	}
 
@@ -128,7 +193,7 @@ func (c *Client) LintFiles(paths []string) ([]FileResult, error) {
 }
 
 func (c *Client) FixString(content, path string) FixResult {
-	f := fixer.New(c.cfg)
+	f := fixer.New(config.ApplyFlavor(c.cfg, path))
 	result := f.Fix(content, path)
 	return FixResult{
 		Changed:    result.Changed,
@@ -155,7 +220,7 @@ func (c *Client) FixFile(path string) (FixResult, error) {
 	result := c.FixString(string(content), path)
 
 	if result.Changed && !c.dryRun {
-		if err := fixer.AtomicWrite(path, []byte(result.Content)); err != nil {
+		if err := c.write(path, content, result.Content); err != nil {
 			return result, WrapWriteError(path, err)
 		}
 	}
@@ -163,15 +228,18 @@ func (c *Client) FixFile(path string) (FixResult, error) {
 	return result, nil
 }
 
+func (c *Client) write(path string, original []byte, content string) error {
+	return fixer.NewPatcher(path).AllowInvalidUTF8(c.allowInvalidUTF8).Expect(original).Apply(content)
+}
+
 func (c *Client) FixFiles(paths []string) ([]FileResult, error) {
-	w := walker.New(c.cfg.Ignore)
+	w := c.newWalker()
 	files, err := w.Walk(paths)
 	if err != nil {
 		return nil, err
 	}
 
 	results := make([]FileResult, 0, len(files))
-	f := fixer.New(c.cfg)
 
 	for _, path := range files {
 		content, err := os.ReadFile(path)
@@ -183,13 +251,13 @@ func (c *Client) FixFiles(paths []string) ([]FileResult, error) {
 			continue
 		}
 
-		fixResult := f.Fix(string(content), path)
+		fixResult := c.FixString(string(content), path)
 
 		if fixResult.Changed && !c.dryRun {
-			if err := fixer.AtomicWrite(path, []byte(fixResult.Content)); err != nil {
+			if err := c.write(path, content, fixResult.Content); err != nil {
 				results = append(results, FileResult{
 					Path:       path,
-					Violations: convertViolations(fixResult.Violations),
+					Violations: fixResult.Violations,
 					Changed:    fixResult.Changed,
 					Error:      WrapWriteError(path, err),
 				})
@@ -199,7 +267,7 @@ func (c *Client) FixFiles(paths []string) ([]FileResult, error) {
 
 		results = append(results, FileResult{
 			Path:       path,
-			Violations: convertViolations(fixResult.Violations),
+			Violations: fixResult.Violations,
 			Changed:    fixResult.Changed,
 		})
 	}
@@ -249,6 +317,8 @@ func convertViolations(violations []rules.Violation) []Violation {
 			Message:   v.Message,
 			Fixable:   v.Fixable,
 			Suggested: v.Suggested,
+			EndColumn: v.EndColumn,
+			Severity:  string(v.Severity),
 		}
 	}
 	return result
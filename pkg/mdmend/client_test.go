@@ -349,6 +349,40 @@ func TestClientLintFiles(t *testing.T) {
 	}
 }
 
+func TestClientLintFilesExtensions(t *testing.T) {
+	tmpDir := t.TempDir()
+
+	files := map[string]string{
+		"doc.md":         "# Doc\n",
+		"guide.markdown": "# Guide\n",
+		"page.mdx":       "# Page\n",
+		"notes.txt":      "plain\n",
+	}
+	for name, content := range files {
+		if err := os.WriteFile(filepath.Join(tmpDir, name), []byte(content), 0644); err != nil {
+			t.Fatalf("failed to write %s: %v", name, err)
+		}
+	}
+
+	client := NewClient()
+	results, err := client.LintFiles([]string{tmpDir})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(results) != 3 {
+		t.Errorf("expected 3 results with default extensions, got %d", len(results))
+	}
+
+	client = NewClient(WithExtensions(".md"), WithInclude("*.txt"))
+	results, err = client.LintFiles([]string{tmpDir})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(results) != 2 {
+		t.Errorf("expected doc.md and notes.txt, got %d results", len(results))
+	}
+}
+
 func TestClientFixFiles(t *testing.T) {
 	tmpDir := t.TempDir()
 
@@ -639,3 +673,16 @@ func TestConvenienceFunctions(t *testing.T) {
 		}
 	})
 }
+
+func TestClientFixLevel(t *testing.T) {
+	content := "# Test\n\n```\ncode\n```\n"
+
+	result := NewClient(WithFixLevel("safe")).FixString(content, "test.md")
+	if result.Changed {
+		t.Errorf("safe fix level should not apply MD040, got %q", result.Content)
+	}
+
+	if client := NewClient(WithFixLevel("everything")); client.ConfigLoadError == nil {
+		t.Error("expected an error for an invalid fix level")
+	}
+}
@@ -5,12 +5,22 @@ import (
 )
 
 type Config struct {
-	Disable    []string
-	Rules      map[string]RuleConfig
-	Ignore     []string
-	TabSize    int
-	Aggressive bool
-	disableSet map[string]bool
+	Disable        []string
+	Enable         []string
+	Only           []string
+	Select         []string
+	Rules          map[string]RuleConfig
+	Ignore         []string
+	Extensions     []string
+	Include        []string
+	MaxFileSize    string
+	MaxDepth       int
+	FollowSymlinks bool
+	EndOfLine      string
+	TabSize        int
+	Aggressive     bool
+	FixLevel       string
+	SlugStyle      string
 }
 
 type RuleConfig struct {
@@ -38,26 +48,33 @@ type RuleConfig struct {
 	Tables                *bool
 	Level                 int
 	SuggestDemotion       *bool
+	Fix                   *bool
+	Vocabulary            string
+	Dictionaries          []string
+	Words                 []string
+	ProhibitedTexts       []string
+	Languages             []string
+	Reflow                string
+	SmallWords            []string
+	Allow                 []string
+	Deny                  []string
+	Timeout               string
+	HostInterval          string
+	CacheTTL              string
+	Concurrency           int
+	Retries               int
+	MaxLevel              int
+	Insert                *bool
 }
 
 func DefaultConfig() *Config {
-	cfg := fromInternalConfig(config.Default())
-	cfg.initDisableSet()
-	return cfg
-}
-
-func (c *Config) initDisableSet() {
-	c.disableSet = make(map[string]bool)
-	for _, id := range c.Disable {
-		c.disableSet[id] = true
-	}
+	return fromInternalConfig(config.Default())
 }
 
+// IsDisabled resolves ruleID against Disable, per-rule Enabled settings,
+// Enable, Only and Select, in that order.
 func (c *Config) IsDisabled(ruleID string) bool {
-	if c.disableSet == nil {
-		c.initDisableSet()
-	}
-	return c.disableSet[ruleID]
+	return !c.toInternal().IsEnabled(ruleID)
 }
 
 func (c *Config) GetRuleConfig(ruleID string) RuleConfig {
@@ -77,9 +94,20 @@ func (c *Config) GetTabSize() int {
 func (c *Config) toInternal() *config.Config {
 	cfg := config.Default()
 	cfg.Disable = c.Disable
+	cfg.Enable = c.Enable
+	cfg.Only = c.Only
+	cfg.Select = c.Select
 	cfg.Ignore = c.Ignore
+	cfg.Extensions = c.Extensions
+	cfg.Include = c.Include
+	cfg.MaxFileSize = c.MaxFileSize
+	cfg.MaxDepth = c.MaxDepth
+	cfg.FollowSymlinks = c.FollowSymlinks
+	cfg.EndOfLine = c.EndOfLine
 	cfg.TabSize = c.TabSize
 	cfg.Aggressive = c.Aggressive
+	cfg.FixLevel = c.FixLevel
+	cfg.SlugStyle = c.SlugStyle
 	if c.Rules != nil {
 		if cfg.Rules == nil {
 			cfg.Rules = make(map[string]config.RuleConfig)
@@ -96,15 +124,24 @@ func fromInternalConfig(cfg *config.Config) *Config {
 	for id, rc := range cfg.Rules {
 		rules[id] = fromInternalRuleConfig(rc)
 	}
-	c := &Config{
-		Disable:    cfg.Disable,
-		Rules:      rules,
-		Ignore:     cfg.Ignore,
-		TabSize:    cfg.TabSize,
-		Aggressive: cfg.Aggressive,
+	return &Config{
+		Disable:        cfg.Disable,
+		Enable:         cfg.Enable,
+		Only:           cfg.Only,
+		Select:         cfg.Select,
+		Rules:          rules,
+		Ignore:         cfg.Ignore,
+		Extensions:     cfg.Extensions,
+		Include:        cfg.Include,
+		MaxFileSize:    cfg.MaxFileSize,
+		MaxDepth:       cfg.MaxDepth,
+		FollowSymlinks: cfg.FollowSymlinks,
+		EndOfLine:      cfg.EndOfLine,
+		TabSize:        cfg.TabSize,
+		Aggressive:     cfg.Aggressive,
+		FixLevel:       cfg.FixLevel,
+		SlugStyle:      cfg.SlugStyle,
 	}
-	c.initDisableSet()
-	return c
 }
 
 func fromInternalRuleConfig(rc config.RuleConfig) RuleConfig {
@@ -133,6 +170,23 @@ func fromInternalRuleConfig(rc config.RuleConfig) RuleConfig {
 		Tables:                rc.Tables,
 		Level:                 rc.Level,
 		SuggestDemotion:       rc.SuggestDemotion,
+		Fix:                   rc.Fix,
+		Vocabulary:            rc.Vocabulary,
+		Dictionaries:          rc.Dictionaries,
+		Words:                 rc.Words,
+		ProhibitedTexts:       rc.ProhibitedTexts,
+		Languages:             rc.Languages,
+		Reflow:                rc.Reflow,
+		SmallWords:            rc.SmallWords,
+		Allow:                 rc.Allow,
+		Deny:                  rc.Deny,
+		Timeout:               rc.Timeout,
+		HostInterval:          rc.HostInterval,
+		CacheTTL:              rc.CacheTTL,
+		Concurrency:           rc.Concurrency,
+		Retries:               rc.Retries,
+		MaxLevel:              rc.MaxLevel,
+		Insert:                rc.Insert,
 	}
 }
 
@@ -162,5 +216,22 @@ func toInternalRuleConfig(rc RuleConfig) config.RuleConfig {
 		Tables:                rc.Tables,
 		Level:                 rc.Level,
 		SuggestDemotion:       rc.SuggestDemotion,
+		Fix:                   rc.Fix,
+		Vocabulary:            rc.Vocabulary,
+		Dictionaries:          rc.Dictionaries,
+		Words:                 rc.Words,
+		ProhibitedTexts:       rc.ProhibitedTexts,
+		Languages:             rc.Languages,
+		Reflow:                rc.Reflow,
+		SmallWords:            rc.SmallWords,
+		Allow:                 rc.Allow,
+		Deny:                  rc.Deny,
+		Timeout:               rc.Timeout,
+		HostInterval:          rc.HostInterval,
+		CacheTTL:              rc.CacheTTL,
+		Concurrency:           rc.Concurrency,
+		Retries:               rc.Retries,
+		MaxLevel:              rc.MaxLevel,
+		Insert:                rc.Insert,
 	}
 }
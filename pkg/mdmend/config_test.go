@@ -183,6 +183,50 @@ func TestWithDryRun(t *testing.T) {
 	}
 }
 
+func TestWithRuleSelection(t *testing.T) {
+	client := NewClient(WithEnabledRules("MD013"), WithRuleSelection("~MD0*", "MD009"))
+	cfg := client.Config()
+
+	tests := map[string]bool{"MD013": true, "MD010": true, "MD009": false}
+	for id, disabled := range tests {
+		if got := cfg.IsDisabled(id); got != disabled {
+			t.Errorf("IsDisabled(%s) = %v, want %v", id, got, disabled)
+		}
+	}
+
+	result := client.LintString("# Title\n\nText with trailing spaces   \n", "test.md")
+	for _, v := range result.Violations {
+		if v.Rule != "MD009" {
+			t.Errorf("unexpected violation from %s", v.Rule)
+		}
+	}
+	if len(result.Violations) == 0 {
+		t.Error("expected MD009 violation")
+	}
+}
+
+func TestWithOverrides(t *testing.T) {
+	client := NewClient(
+		WithEnvOverrides([]string{"MDMEND_MD013_LINE_LENGTH=120", "MDMEND_MD048_STYLE=tilde"}),
+		WithOverrides("MD013.line_length=100"),
+	)
+	if client.ConfigLoadError != nil {
+		t.Fatalf("unexpected error: %v", client.ConfigLoadError)
+	}
+	cfg := client.Config()
+	if got := cfg.GetRuleConfig("MD013").LineLength; got != 100 {
+		t.Errorf("MD013 line length = %d, want 100", got)
+	}
+	if got := cfg.GetRuleConfig("MD048").Style; got != "tilde" {
+		t.Errorf("MD048 style = %q, want tilde", got)
+	}
+
+	client = NewClient(WithOverrides("MD013.line_length=wide"))
+	if !IsConfigError(client.ConfigLoadError) {
+		t.Errorf("ConfigLoadError = %v, want config error", client.ConfigLoadError)
+	}
+}
+
 func TestWithHeadingStyle(t *testing.T) {
 	client := NewClient(WithHeadingStyle("atx_closed"))
 	cfg := client.Config()
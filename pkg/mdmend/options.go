@@ -7,14 +7,26 @@ import (
 type Option func(*clientOptions)
 
 type clientOptions struct {
-	cfg           *config.Config
-	configPath    string
-	disabled      []string
-	ignore        []string
-	tabSize       int
-	aggressive    *bool
-	dryRun        *bool
-	ruleOverrides map[string]config.RuleConfig
+	cfg              *config.Config
+	configPath       string
+	disabled         []string
+	enabled          []string
+	selection        []string
+	ignore           []string
+	extensions       []string
+	include          []string
+	tabSize          int
+	aggressive       *bool
+	fixLevel         string
+	slugStyle        string
+	dryRun           *bool
+	allowInvalidUTF8 bool
+	ruleOverrides    map[string]config.RuleConfig
+	overrides        []string
+	noIgnoreFiles    bool
+	noGitignore      bool
+	environ          []string
+	useEnv           bool
 }
 
 func WithConfig(cfg *Config) Option {
@@ -37,12 +49,57 @@ func WithDisabledRules(rules ...string) Option {
 	}
 }
 
+func WithEnabledRules(rules ...string) Option {
+	return func(o *clientOptions) {
+		o.enabled = append(o.enabled, rules...)
+	}
+}
+
+// WithRuleSelection applies selection expressions in order, as accepted by
+// the CLI's --rules flag, e.g. "MD013", "~links" or "=MD0*".
+func WithRuleSelection(exprs ...string) Option {
+	return func(o *clientOptions) {
+		o.selection = append(o.selection, exprs...)
+	}
+}
+
 func WithIgnorePatterns(patterns ...string) Option {
 	return func(o *clientOptions) {
 		o.ignore = append(o.ignore, patterns...)
 	}
 }
 
+// WithExtensions replaces the file extensions LintFiles and FixFiles pick up
+// when walking directories, e.g. ".md", ".mdx".
+func WithExtensions(exts ...string) Option {
+	return func(o *clientOptions) {
+		o.extensions = append(o.extensions, exts...)
+	}
+}
+
+// WithInclude adds glob patterns for files to walk regardless of extension.
+func WithInclude(patterns ...string) Option {
+	return func(o *clientOptions) {
+		o.include = append(o.include, patterns...)
+	}
+}
+
+// WithIgnoreFiles controls whether LintFiles and FixFiles honour
+// .gitignore, .mdmendignore and .git/info/exclude. Enabled by default.
+func WithIgnoreFiles(enabled bool) Option {
+	return func(o *clientOptions) {
+		o.noIgnoreFiles = !enabled
+	}
+}
+
+// WithRespectGitignore controls whether .gitignore and .git/info/exclude are
+// honoured; .mdmendignore still applies when disabled. Enabled by default.
+func WithRespectGitignore(enabled bool) Option {
+	return func(o *clientOptions) {
+		o.noGitignore = !enabled
+	}
+}
+
 func WithTabSize(size int) Option {
 	return func(o *clientOptions) {
 		if size <= 0 {
@@ -59,6 +116,23 @@ func WithAggressiveMode(enabled bool) Option {
 	}
 }
 
+// WithFixLevel limits fixes to "safe" (whitespace and structure only) or
+// allows every fix with "unsafe", the default.
+func WithFixLevel(level string) Option {
+	return func(o *clientOptions) {
+		o.fixLevel = level
+	}
+}
+
+// WithSlugStyle sets the heading anchor scheme MD051, MD057 and MD073
+// check fragments against: github, gitlab, mkdocs, docusaurus, hugo or
+// pandoc. By default the flavor picks one.
+func WithSlugStyle(style string) Option {
+	return func(o *clientOptions) {
+		o.slugStyle = style
+	}
+}
+
 func WithDryRun(enabled bool) Option {
 	return func(o *clientOptions) {
 		v := enabled
@@ -66,6 +140,14 @@ func WithDryRun(enabled bool) Option {
 	}
 }
 
+// WithAllowInvalidUTF8 lets FixFile and FixFiles write files that are not
+// valid UTF-8. By default such writes fail with a write error.
+func WithAllowInvalidUTF8(allow bool) Option {
+	return func(o *clientOptions) {
+		o.allowInvalidUTF8 = allow
+	}
+}
+
 // WithRuleConfig sets a specific rule's configuration.
 // Note: Calling WithConfig after this will overwrite these settings,
 // as WithConfig replaces the entire configuration object.
@@ -78,6 +160,23 @@ func WithRuleConfig(ruleID string, rc RuleConfig) Option {
 	}
 }
 
+// WithOverrides applies "RULE.key=value" expressions, as accepted by the
+// CLI's --set flag, after all other configuration.
+func WithOverrides(exprs ...string) Option {
+	return func(o *clientOptions) {
+		o.overrides = append(o.overrides, exprs...)
+	}
+}
+
+// WithEnvOverrides applies MDMEND_<RULE>_<KEY> variables from environ, or
+// from the process environment when environ is nil.
+func WithEnvOverrides(environ []string) Option {
+	return func(o *clientOptions) {
+		o.useEnv = true
+		o.environ = environ
+	}
+}
+
 func WithHeadingStyle(style string) Option {
 	return WithRuleConfig("MD003", RuleConfig{Style: style})
 }
@@ -20,6 +20,9 @@ type RuleInfo struct {
 	Name        string
 	Description string
 	Fixable     bool
+	Aliases     []string
+	Tags        []string
+	DocURL      string
 }
 
 func toRuleInfo(r rules.Rule) RuleInfo {
@@ -28,6 +31,9 @@ func toRuleInfo(r rules.Rule) RuleInfo {
 		Name:        r.Name(),
 		Description: r.Description(),
 		Fixable:     r.Fixable(),
+		Aliases:     rules.Aliases(r.ID()),
+		Tags:        rules.Tags(r.ID()),
+		DocURL:      rules.DocURL(r.ID()),
 	}
 }
 
@@ -44,6 +50,20 @@ func RuleIDs() []string {
 	return rules.IDs()
 }
 
+// RulesByTag returns the rules carrying tag, e.g. "links" or "headings".
+func RulesByTag(tag string) []RuleInfo {
+	var result []RuleInfo
+	for _, r := range rules.All() {
+		for _, t := range rules.Tags(r.ID()) {
+			if strings.EqualFold(t, tag) {
+				result = append(result, toRuleInfo(r))
+				break
+			}
+		}
+	}
+	return result
+}
+
 func GetRuleInfo(id string) (RuleInfo, bool) {
 	r := rules.Get(id)
 	if r == nil {
@@ -84,6 +104,8 @@ func (a *ruleAdapter) Lint(content string, path string) []rules.Violation {
 			Message:   v.Message,
 			Fixable:   v.Fixable,
 			Suggested: v.Suggested,
+			EndColumn: v.EndColumn,
+			Severity:  rules.Severity(v.Severity),
 		}
 	}
 	return result
@@ -145,6 +145,23 @@ func TestFixableRules(t *testing.T) {
 	}
 }
 
+func TestRulesByTag(t *testing.T) {
+	tagged := RulesByTag("links")
+	if len(tagged) == 0 {
+		t.Fatal("expected rules tagged links")
+	}
+	for _, r := range tagged {
+		if r.DocURL == "" || len(r.Aliases) == 0 {
+			t.Errorf("%s missing metadata: %+v", r.ID, r)
+		}
+	}
+
+	cfg := &Config{Disable: []string{"no-bare-urls"}}
+	if !cfg.IsDisabled("MD034") {
+		t.Error("expected alias no-bare-urls to disable MD034")
+	}
+}
+
 func TestRegisterRule(t *testing.T) {
 	t.Run("nil rule", func(t *testing.T) {
 		err := RegisterRule(nil)
@@ -9,6 +9,8 @@ type Violation struct {
 	Message   string
 	Fixable   bool
 	Suggested string
+	EndColumn int
+	Severity  string
 }
 
 func (v Violation) String() string {